@@ -0,0 +1,156 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/astroband/astrologer/source"
+	"github.com/astroband/astrologer/util"
+	"github.com/stellar/go/xdr"
+)
+
+// checkpoint represents ledgers of a single checkpoint
+type checkpoint struct {
+	ledgers map[int]*source.LedgerCloseData
+}
+
+// readXdrStream reads gzipped stream of XDR records calling fn for each record body
+func readXdrStream(path string, fn func(record []byte) error) error {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	for {
+		record, err := util.ReadXdrRecord(r)
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// ledgerSeqs reads seqnums of ledger headers stored in the checkpoint without loading its transactions
+func (c *Client) ledgerSeqs(chk int) ([]int, error) {
+	var seqs []int
+
+	err := readXdrStream(c.categoryPath("ledger", chk), func(record []byte) error {
+		var entry xdr.LedgerHeaderHistoryEntry
+
+		if err := xdr.SafeUnmarshal(record, &entry); err != nil {
+			return err
+		}
+
+		seqs = append(seqs, int(entry.Header.LedgerSeq))
+
+		return nil
+	})
+
+	return seqs, err
+}
+
+// loadCheckpoint reads ledger headers, transactions and results files of the checkpoint
+func (c *Client) loadCheckpoint(chk int) (*checkpoint, error) {
+	result := &checkpoint{
+		ledgers: make(map[int]*source.LedgerCloseData),
+	}
+
+	envelopes := make(map[int]map[xdr.Hash]xdr.TransactionEnvelope)
+
+	err := readXdrStream(c.categoryPath("ledger", chk), func(record []byte) error {
+		var entry xdr.LedgerHeaderHistoryEntry
+
+		if err := xdr.SafeUnmarshal(record, &entry); err != nil {
+			return err
+		}
+
+		result.ledgers[int(entry.Header.LedgerSeq)] = &source.LedgerCloseData{Header: entry}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = readXdrStream(c.categoryPath("transactions", chk), func(record []byte) error {
+		var entry xdr.TransactionHistoryEntry
+
+		err := xdr.SafeUnmarshal(record, &entry)
+
+		if err != nil {
+			return err
+		}
+
+		envelopes[int(entry.LedgerSeq)], err = source.EnvelopesByHash(entry.TxSet.Txs, c.passphrase)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = readXdrStream(c.categoryPath("results", chk), func(record []byte) error {
+		var entry xdr.TransactionHistoryResultEntry
+
+		if err := xdr.SafeUnmarshal(record, &entry); err != nil {
+			return err
+		}
+
+		seq := int(entry.LedgerSeq)
+		ledger, ok := result.ledgers[seq]
+
+		if !ok {
+			return fmt.Errorf("Header of ledger %d not found", seq)
+		}
+
+		// Results are stored in the apply order, which is the order of txhistory rows in core
+		for n, pair := range entry.TxResultSet.Results {
+			envelope, ok := envelopes[seq][pair.TransactionHash]
+
+			if !ok {
+				return fmt.Errorf("Transaction %x of ledger %d not found, check network passphrase", pair.TransactionHash, seq)
+			}
+
+			// Archives contain neither metas nor fee changes
+			ledger.Transactions = append(ledger.Transactions, source.Transaction{
+				Hash:     hex.EncodeToString(pair.TransactionHash[:]),
+				Index:    n + 1,
+				Envelope: envelope,
+				Result:   pair,
+				Meta:     xdr.TransactionMeta{Operations: &[]xdr.OperationMeta{}},
+			})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+const fixturePassphrase = "Test SDF Network ; September 2015"
+
+// writeXdrStream writes records as gzipped stream of framed XDR records, the way stellar-core does
+func writeXdrStream(t *testing.T, path string, records ...interface{}) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := gzip.NewWriter(f)
+
+	for _, record := range records {
+		var body bytes.Buffer
+
+		if _, err := xdr.Marshal(&body, record); err != nil {
+			t.Fatal(err)
+		}
+
+		header := make([]byte, 4)
+		binary.BigEndian.PutUint32(header, uint32(body.Len())|0x80000000)
+
+		w.Write(header)
+		w.Write(body.Bytes())
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newFixtureArchive creates the archive with the single checkpoint 63 holding ledgers 62 and 63, the
+// latter having one transaction. Caller removes the archive root.
+func newFixtureArchive(t *testing.T) (*Client, xdr.Hash) {
+	root, err := ioutil.TempDir("", "astrologer-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var key xdr.Uint256
+
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &key},
+				Fee:           100,
+				SeqNum:        1,
+			},
+		},
+	}
+
+	hash, err := network.HashTransactionInEnvelope(envelope, fixturePassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Client{root: root, passphrase: fixturePassphrase, checkpoints: make(map[int]*checkpoint)}
+
+	writeXdrStream(
+		t, client.categoryPath("ledger", 63),
+		xdr.LedgerHeaderHistoryEntry{Header: xdr.LedgerHeader{LedgerSeq: 62}},
+		xdr.LedgerHeaderHistoryEntry{Header: xdr.LedgerHeader{LedgerSeq: 63}},
+	)
+
+	writeXdrStream(
+		t, client.categoryPath("transactions", 63),
+		xdr.TransactionHistoryEntry{
+			LedgerSeq: 63,
+			TxSet:     xdr.TransactionSet{Txs: []xdr.TransactionEnvelope{envelope}},
+		},
+	)
+
+	writeXdrStream(
+		t, client.categoryPath("results", 63),
+		xdr.TransactionHistoryResultEntry{
+			LedgerSeq: 63,
+			TxResultSet: xdr.TransactionResultSet{
+				Results: []xdr.TransactionResultPair{{
+					TransactionHash: xdr.Hash(hash),
+					Result: xdr.TransactionResult{
+						FeeCharged: 100,
+						Result: xdr.TransactionResultResult{
+							Code:    xdr.TransactionResultCodeTxSuccess,
+							Results: &[]xdr.OperationResult{},
+						},
+					},
+				}},
+			},
+		},
+	)
+
+	state := filepath.Join(root, ".well-known", "stellar-history.json")
+
+	if err := os.MkdirAll(filepath.Dir(state), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(state, []byte(`{"currentLedger": 63}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return client, xdr.Hash(hash)
+}
+
+func TestLedgerRange(t *testing.T) {
+	client, hash := newFixtureArchive(t)
+	defer os.RemoveAll(client.root)
+
+	if first := client.FirstLedgerSeq(); first != 62 {
+		t.Errorf("FirstLedgerSeq() = %d, want 62", first)
+	}
+
+	if last := client.LastLedgerSeq(); last != 63 {
+		t.Errorf("LastLedgerSeq() = %d, want 63", last)
+	}
+
+	// Checkpoint 63 holds only ledgers 62 and 63
+	if seqs := client.LedgerSeqsInRange(0, 0); len(seqs) != 2 || seqs[0] != 62 || seqs[1] != 63 {
+		t.Errorf("LedgerSeqsInRange(0, 0) = %v, want [62 63]", seqs)
+	}
+
+	ledgers := client.LedgerRange(62, 63)
+
+	if len(ledgers) != 2 {
+		t.Fatalf("LedgerRange(62, 63) returned %d ledgers, want 2", len(ledgers))
+	}
+
+	if len(ledgers[0].Transactions) != 0 {
+		t.Errorf("ledger 62 has %d transactions, want 0", len(ledgers[0].Transactions))
+	}
+
+	txs := ledgers[1].Transactions
+
+	if len(txs) != 1 {
+		t.Fatalf("ledger 63 has %d transactions, want 1", len(txs))
+	}
+
+	if txs[0].Hash != hex.EncodeToString(hash[:]) {
+		t.Errorf("transaction hash = %s, want %x", txs[0].Hash, hash)
+	}
+
+	if txs[0].Index != 1 || txs[0].Result.Result.FeeCharged != 100 {
+		t.Errorf("transaction index %d, fee charged %d, want 1 and 100", txs[0].Index, txs[0].Result.Result.FeeCharged)
+	}
+}
+
+func TestLoadCheckpointWithoutResults(t *testing.T) {
+	client, _ := newFixtureArchive(t)
+	defer os.RemoveAll(client.root)
+
+	if err := os.Remove(client.categoryPath("results", 63)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.loadCheckpoint(63); err == nil {
+		t.Error("loadCheckpoint succeeded without results file")
+	}
+}
+
+// writeHeaders writes ledger headers file of the checkpoint holding ledgers from first to the checkpoint ledger
+func writeHeaders(t *testing.T, client *Client, first, chk int) {
+	headers := []interface{}{}
+
+	for seq := first; seq <= chk; seq++ {
+		headers = append(headers, xdr.LedgerHeaderHistoryEntry{Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(seq)}})
+	}
+
+	writeXdrStream(t, client.categoryPath("ledger", chk), headers...)
+}
+
+func TestFirstLedgerSeqSkipsMissingCheckpoints(t *testing.T) {
+	client, _ := newFixtureArchive(t)
+	defer os.RemoveAll(client.root)
+
+	if err := os.Remove(client.categoryPath("ledger", 63)); err != nil {
+		t.Fatal(err)
+	}
+
+	writeHeaders(t, client, 64, 127)
+	writeHeaders(t, client, 128, 191)
+
+	state := filepath.Join(client.root, ".well-known", "stellar-history.json")
+
+	if err := ioutil.WriteFile(state, []byte(`{"currentLedger": 191}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if first := client.FirstLedgerSeq(); first != 64 {
+		t.Errorf("FirstLedgerSeq() = %d, want 64", first)
+	}
+
+	if seqs := client.LedgerSeqsInRange(120, 130); len(seqs) != 11 || seqs[0] != 120 || seqs[10] != 130 {
+		t.Errorf("LedgerSeqsInRange(120, 130) = %v, want 120 to 130", seqs)
+	}
+}
@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"log"
+	"sort"
+
+	"github.com/astroband/astrologer/source"
+)
+
+// ledger returns ledger for the given seq, nil if the ledger is not published yet
+func (c *Client) ledger(seq int) *source.LedgerCloseData {
+	chk := c.getCheckpoint(checkpointFor(seq))
+
+	if chk == nil {
+		return nil
+	}
+
+	return chk.ledgers[seq]
+}
+
+// FirstLedgerSeq returns the first ledger of the first checkpoint present in the archive. Archive is
+// expected to hold every checkpoint starting with the first one up to the current ledger, so the first
+// checkpoint is searched for with binary search.
+func (c *Client) FirstLedgerSeq() int {
+	count := checkpointFor(c.currentLedger())/checkpointFrequency + 1
+
+	n := sort.Search(count, func(n int) bool {
+		return c.checkpointExists(n*checkpointFrequency + checkpointFrequency - 1)
+	})
+
+	if n == count {
+		return 0
+	}
+
+	seqs := c.checkpointSeqs(n*checkpointFrequency + checkpointFrequency - 1)
+
+	if len(seqs) == 0 {
+		return 0
+	}
+
+	return seqs[0]
+}
+
+// checkpointSeqs returns sorted seqnums of ledger headers stored in the checkpoint
+func (c *Client) checkpointSeqs(chk int) []int {
+	seqs, err := c.ledgerSeqs(chk)
+
+	if err != nil {
+		log.Fatalf("Failed to read ledger headers of checkpoint %d: %v", chk, err)
+	}
+
+	sort.Ints(seqs)
+
+	return seqs
+}
+
+// LastLedgerSeq returns the last ledger published to the archive
+func (c *Client) LastLedgerSeq() int {
+	return c.currentLedger()
+}
+
+// LedgerCount returns total ledgers count within given range
+func (c *Client) LedgerCount(first, last int) int {
+	return len(c.LedgerSeqsInRange(first, last))
+}
+
+// LedgerSeqsInRange returns seqnums of ledgers published to the archive within given range
+func (c *Client) LedgerSeqsInRange(first, last int) []int {
+	seqs := []int{}
+	current := c.currentLedger()
+
+	if last == 0 || last > current {
+		last = current
+	}
+
+	for chk := checkpointFor(first); chk <= checkpointFor(last); chk += checkpointFrequency {
+		if !c.checkpointExists(chk) {
+			continue
+		}
+
+		for _, seq := range c.checkpointSeqs(chk) {
+			if seq >= first && seq <= last {
+				seqs = append(seqs, seq)
+			}
+		}
+	}
+
+	return seqs
+}
+
+// LedgerRange returns ledgers within given range
+func (c *Client) LedgerRange(first, last int) []source.LedgerCloseData {
+	return c.LedgersForSeqs(c.LedgerSeqsInRange(first, last))
+}
+
+// LedgersForSeqs returns ledgers with given seqnums
+func (c *Client) LedgersForSeqs(seqs []int) []source.LedgerCloseData {
+	ledgers := []source.LedgerCloseData{}
+
+	for _, seq := range seqs {
+		if ledger := c.ledger(seq); ledger != nil {
+			ledgers = append(ledgers, *ledger)
+		}
+	}
+
+	return ledgers
+}
+
+// NextLedger returns the ledger following seq, nil if it is not published yet
+func (c *Client) NextLedger(seq int) *source.LedgerCloseData {
+	return c.ledger(seq + 1)
+}
@@ -0,0 +1,134 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	checkpointFrequency = 64
+	checkpointCacheSize = 16
+)
+
+// Client is a source.LedgerSource implementation reading ledgers from the history archive stored on disk
+type Client struct {
+	root       string
+	passphrase string
+
+	checkpoints map[int]*checkpoint
+	mutex       sync.Mutex
+}
+
+// historyArchiveState represents the root history archive state file
+type historyArchiveState struct {
+	CurrentLedger int `json:"currentLedger"`
+}
+
+// Connect returns the Client reading the archive located at the given path or file:// URL
+func Connect(archiveURL string, passphrase string) *Client {
+	u, err := url.Parse(archiveURL)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	root := archiveURL
+
+	switch u.Scheme {
+	case "file":
+		root = u.Path
+	case "":
+	default:
+		log.Fatalf("Unsupported history archive scheme %s, only local paths and file:// URLs are supported", u.Scheme)
+	}
+
+	if _, err := os.Stat(root); err != nil {
+		log.Fatal(err)
+	}
+
+	return &Client{
+		root:        root,
+		passphrase:  passphrase,
+		checkpoints: make(map[int]*checkpoint),
+	}
+}
+
+// checkpointFor returns the checkpoint ledger containing given ledger
+func checkpointFor(seq int) int {
+	return (seq/checkpointFrequency+1)*checkpointFrequency - 1
+}
+
+// categoryPath returns path to the checkpoint file of the given category, eg. ledger/00/00/3f/ledger-0000003f.xdr.gz
+func (c *Client) categoryPath(category string, chk int) string {
+	hex := fmt.Sprintf("%08x", chk)
+
+	return filepath.Join(
+		c.root, category, hex[0:2], hex[2:4], hex[4:6],
+		fmt.Sprintf("%s-%s.xdr.gz", category, hex),
+	)
+}
+
+// checkpointExists checks if the ledger headers file for checkpoint exists in the archive
+func (c *Client) checkpointExists(chk int) bool {
+	_, err := os.Stat(c.categoryPath("ledger", chk))
+	return err == nil
+}
+
+// currentLedger returns the last ledger published to the archive
+func (c *Client) currentLedger() int {
+	var has historyArchiveState
+
+	f, err := os.Open(filepath.Join(c.root, ".well-known", "stellar-history.json"))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(&has); err != nil {
+		log.Fatalf("Error parsing history archive state: %s", err)
+	}
+
+	return has.CurrentLedger
+}
+
+// getCheckpoint returns cached checkpoint data or loads it from disk, nil if checkpoint is not published yet
+func (c *Client) getCheckpoint(chk int) *checkpoint {
+	c.mutex.Lock()
+	cached, ok := c.checkpoints[chk]
+	c.mutex.Unlock()
+
+	if ok {
+		return cached
+	}
+
+	if !c.checkpointExists(chk) {
+		return nil
+	}
+
+	loaded, err := c.loadCheckpoint(chk)
+
+	if err != nil {
+		log.Fatalf("Failed to load checkpoint %d: %v", chk, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.checkpoints) >= checkpointCacheSize {
+		for key := range c.checkpoints {
+			delete(c.checkpoints, key)
+			break
+		}
+	}
+
+	c.checkpoints[chk] = loaded
+
+	return loaded
+}
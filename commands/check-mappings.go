@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/astroband/astrologer/es"
+)
+
+// CheckMappingsCommandConfig represents the configuration options for the `check-mappings` command
+type CheckMappingsCommandConfig struct {
+	Live bool
+}
+
+// CheckMappingsCommand represents the `check-mappings` CLI command
+type CheckMappingsCommand struct {
+	ES     es.Adapter
+	Config CheckMappingsCommandConfig
+}
+
+// Execute compares JSON fields of documents with index definitions and, optionally, with mappings
+// of the live cluster. Exits with non-zero status if any difference is found.
+func (cmd *CheckMappingsCommand) Execute() {
+	definitions := es.GetIndexDefinitions()
+	reports := es.CheckMappings(definitions)
+
+	if cmd.Config.Live {
+		reports = append(reports, cmd.liveReports(definitions)...)
+	}
+
+	failed := 0
+
+	for _, report := range reports {
+		if report.OK() {
+			fmt.Printf("%s: ok\n", report.Index)
+			continue
+		}
+
+		failed++
+
+		if len(report.Unmapped) > 0 {
+			fmt.Printf("%s: unmapped fields: %s\n", report.Index, strings.Join(report.Unmapped, ", "))
+		}
+
+		if len(report.Orphaned) > 0 {
+			fmt.Printf("%s: orphaned fields: %s\n", report.Index, strings.Join(report.Orphaned, ", "))
+		}
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d of %d mappings are inconsistent", failed, len(reports))
+	}
+
+	fmt.Println("Mappings are consistent!")
+}
+
+// liveReports compares documents with mappings of every physical index behind the alias
+func (cmd *CheckMappingsCommand) liveReports(definitions map[es.IndexName]es.IndexDefinition) []es.MappingReport {
+	var (
+		reports []es.MappingReport
+		names   []string
+	)
+
+	for name := range definitions {
+		names = append(names, string(name))
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		alias := cmd.ES.Indices().Name(es.IndexName(name))
+		mappings := cmd.ES.GetMappings(alias)
+
+		if len(mappings) == 0 {
+			log.Printf("%s index not found, skipping...", alias)
+			continue
+		}
+
+		for index, mapping := range mappings {
+			reports = append(reports, es.CheckMapping(es.IndexName(name), index, mapping))
+		}
+	}
+
+	return reports
+}
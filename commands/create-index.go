@@ -9,7 +9,8 @@ import (
 
 // CreateIndexCommandConfig represents the configuration options for the `create-index` command
 type CreateIndexCommandConfig struct {
-	Force bool
+	Force     bool
+	Partition es.Partitioning
 }
 
 // CreateIndexCommand represents the `create-index` CLI command
@@ -18,25 +19,61 @@ type CreateIndexCommand struct {
 	Config CreateIndexCommandConfig
 }
 
-// Execute creates Astrologer indices in ElasticSearch
+// Execute creates versioned Astrologer indices in ElasticSearch behind aliases, names and settings
+// are taken from the index config
 func (cmd *CreateIndexCommand) Execute() {
+	indices := cmd.ES.Indices()
+
 	for name, def := range es.GetIndexDefinitions() {
-		cmd.refreshIndex(name, def)
+		alias := indices.Name(name)
+		target := es.VersionedIndexName(alias, es.CurrentIndexVersion(name))
+		def = indices.Definition(name, def)
+
+		if cmd.Config.Partition.IsPartitioned(name) {
+			cmd.putTemplate(alias, target, def)
+			continue
+		}
+
+		cmd.refreshIndex(alias, target, def)
 	}
 	fmt.Println("Indicies created successfully!")
 }
 
-func (cmd *CreateIndexCommand) refreshIndex(name es.IndexName, schema es.IndexDefinition) {
-	if !cmd.ES.IndexExists(name) {
-		cmd.ES.CreateIndex(name, schema)
-		log.Printf("%s index created!", name)
-	} else {
-		if cmd.Config.Force {
-			cmd.ES.DeleteIndex(name)
-			cmd.ES.CreateIndex(name, schema)
-			log.Printf("%s index recreated!", name)
-		} else {
-			log.Printf("%s index found, skipping...", name)
+func (cmd *CreateIndexCommand) refreshIndex(name es.IndexName, target es.IndexName, schema es.IndexDefinition) {
+	current := cmd.ES.AliasTarget(name)
+
+	switch {
+	case current == target:
+		log.Printf("%s index found, skipping...", name)
+	case current != "":
+		log.Printf("%s points to %s, run migrate to move it to %s", name, current, target)
+	case cmd.ES.IndexExists(name):
+		log.Printf("%s is not versioned, run migrate to move it to %s", name, target)
+	default:
+		createIndexVersion(cmd.ES, target, schema, cmd.Config.Force)
+		cmd.ES.PutAlias(name, target)
+		log.Printf("%s index created as %s!", name, target)
+	}
+}
+
+// putTemplate provisions the template partitions of the index are created from on first write
+func (cmd *CreateIndexCommand) putTemplate(name es.IndexName, target es.IndexName, schema es.IndexDefinition) {
+	cmd.ES.PutTemplate(target, es.PartitionTemplate(name, target, schema, cmd.Config.Partition))
+
+	log.Printf("%s template created for %s-* partitions!", name, target)
+}
+
+// createIndexVersion creates physical index, existing one is reused unless force is set. It is never
+// called for indices behind aliases, so it may only drop leftovers of interrupted runs.
+func createIndexVersion(adapter es.Adapter, index es.IndexName, schema es.IndexDefinition, force bool) {
+	if adapter.IndexExists(index) {
+		if !force {
+			log.Printf("%s index found, reusing...", index)
+			return
 		}
+
+		adapter.DeleteIndex(index)
 	}
+
+	adapter.CreateIndex(index, schema)
 }
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/astroband/astrologer/sink"
+)
+
+// CreateTablesCommandConfig represents the configuration options for the `create-tables` command
+type CreateTablesCommandConfig struct {
+	Force bool
+}
+
+// CreateTablesCommand represents the `create-tables` CLI command
+type CreateTablesCommand struct {
+	Postgres *sink.Postgres
+	Config   CreateTablesCommandConfig
+}
+
+// Execute creates Astrologer tables in the Postgres sink database
+func (cmd *CreateTablesCommand) Execute() {
+	cmd.Postgres.CreateTables(cmd.Config.Force)
+	fmt.Println("Tables created successfully!")
+}
@@ -1,16 +1,16 @@
 package commands
 
 import (
-	"bytes"
+	"encoding/json"
 	"log"
-	"math/rand"
 	"time"
 
 	progressbar "github.com/schollz/progressbar/v2"
 
 	"github.com/astroband/astrologer/config"
-	"github.com/astroband/astrologer/db"
 	"github.com/astroband/astrologer/es"
+	"github.com/astroband/astrologer/sink"
+	"github.com/astroband/astrologer/source"
 )
 
 var (
@@ -19,17 +19,24 @@ var (
 
 // ExportCommandConfig represents configuration options for `export` CLI command
 type ExportCommandConfig struct {
-	Start      config.NumberWithSign
-	Count      int
-	RetryCount int
-	DryRun     bool
-	BatchSize  int
+	Start     config.NumberWithSign
+	Count     int
+	DryRun    bool
+	BatchSize int
+	RawXdr    bool
+
+	NetworkPassphrase string
+}
+
+// sequentialSource is implemented by ledger sources which can only be read in order, like meta stream pipes
+type sequentialSource interface {
+	Sequential() bool
 }
 
 // ExportCommand represents the `export` CLI command
 type ExportCommand struct {
-	ES     es.Adapter
-	DB     db.Adapter
+	Source source.LedgerSource
+	Sink   sink.Sink
 	Config ExportCommandConfig
 
 	firstLedger int
@@ -40,7 +47,7 @@ type ExportCommand struct {
 func (cmd *ExportCommand) Execute() {
 	cmd.firstLedger, cmd.lastLedger = cmd.getRange()
 
-	total := cmd.DB.LedgerHeaderRowCount(cmd.firstLedger, cmd.lastLedger)
+	total := cmd.Source.LedgerCount(cmd.firstLedger, cmd.lastLedger)
 
 	if total == 0 {
 		log.Fatal("Nothing to export within given range!", cmd.firstLedger, cmd.lastLedger)
@@ -52,76 +59,95 @@ func (cmd *ExportCommand) Execute() {
 
 	for i := 0; i < cmd.blockCount(total); i++ {
 		i := i
-		pool.Submit(func() { cmd.exportBlock(i) })
+		cmd.submit(func() { cmd.exportBlock(i) })
 	}
 
 	pool.StopWait()
 	finishBar()
+
+	if !cmd.Config.DryRun {
+		cmd.Sink.Close()
+	}
+}
+
+// submit runs the task in the worker pool, tasks reading sequential sources are run one by one in order
+func (cmd *ExportCommand) submit(task func()) {
+	if s, ok := cmd.Source.(sequentialSource); ok && s.Sequential() {
+		task()
+		return
+	}
+
+	pool.Submit(task)
 }
 
 func (cmd *ExportCommand) exportBlock(i int) {
-	var b bytes.Buffer
+	low := cmd.firstLedger + i*cmd.Config.BatchSize
+	high := low + cmd.Config.BatchSize - 1
 
-	rows := cmd.DB.LedgerHeaderRowFetchBatch(i, cmd.firstLedger, cmd.Config.BatchSize)
+	cmd.exportLedgers(cmd.Source.LedgerRange(low, high))
+}
 
-	for n := 0; n < len(rows); n++ {
-		txs := cmd.DB.TxHistoryRowForSeq(rows[n].LedgerSeq)
-		fees := cmd.DB.TxFeeHistoryRowsForRows(txs)
+// exportLedgers converts given ledgers into documents and writes them to the sink in a single batch
+func (cmd *ExportCommand) exportLedgers(ledgers []source.LedgerCloseData) {
+	var docs []es.Indexable
 
-		err := es.SerializeLedger(rows[n], txs, fees, &b)
+	for _, ledger := range ledgers {
+		ledgerDocs, err := es.ProduceDocuments(ledger, es.ProduceOptions{
+			RawXdr:            cmd.Config.RawXdr,
+			NetworkPassphrase: cmd.Config.NetworkPassphrase,
+		})
 
 		if err != nil {
-			log.Fatalf("Failed to ingest ledger %d: %v\n", rows[n].LedgerSeq, err)
+			log.Fatalf("Failed to ingest ledger %d: %v\n", ledger.Seq(), err)
 		}
 
+		docs = append(docs, ledgerDocs...)
+
 		if !*config.Verbose {
 			bar.Add(1)
 		}
 	}
 
 	if *config.Verbose {
-		log.Println(b.String())
+		printDocuments(docs)
 	}
 
 	if !cmd.Config.DryRun {
-		cmd.ES.IndexWithRetries(&b, cmd.Config.RetryCount)
+		cmd.Sink.Write(docs)
 	}
 }
 
-func (cmd *ExportCommand) index(b *bytes.Buffer, retry int) {
-	indexed := cmd.ES.BulkInsert(b)
+func printDocuments(docs []es.Indexable) {
+	for _, doc := range docs {
+		data, err := json.Marshal(doc)
 
-	if !indexed {
-		if retry > cmd.Config.RetryCount {
-			log.Fatal("Retries for bulk failed, aborting")
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		delay := time.Duration((rand.Intn(10) + 5))
-		time.Sleep(delay * time.Second)
-
-		cmd.index(b, retry+1)
+		log.Println(doc.IndexName(), string(data))
 	}
 }
 
 // Parses range of export command
 func (cmd *ExportCommand) getRange() (first int, last int) {
-	firstLedger := cmd.DB.LedgerHeaderFirstRow()
-	lastLedger := cmd.DB.LedgerHeaderLastRow()
+	firstLedger := cmd.Source.FirstLedgerSeq()
+	lastLedger := cmd.Source.LastLedgerSeq()
 
 	if cmd.Config.Start.Explicit {
 		if cmd.Config.Start.Value < 0 {
-			first = lastLedger.LedgerSeq + cmd.Config.Start.Value + 1
-		} else if config.Start.Value > 0 {
-			first = firstLedger.LedgerSeq + cmd.Config.Start.Value
+			first = lastLedger + cmd.Config.Start.Value + 1
+		} else if cmd.Config.Start.Value > 0 {
+			first = firstLedger + cmd.Config.Start.Value
 		}
 	} else if cmd.Config.Start.Value != 0 {
 		first = cmd.Config.Start.Value
 	} else {
-		first = firstLedger.LedgerSeq
+		first = firstLedger
 	}
 
 	if cmd.Config.Count == 0 {
-		last = lastLedger.LedgerSeq
+		last = lastLedger
 	} else {
 		last = first + cmd.Config.Count - 1
 	}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"log"
+
+	"github.com/astroband/astrologer/es"
+	"github.com/astroband/astrologer/sink"
+	"github.com/astroband/astrologer/source"
+)
+
+// gapsWindow is the number of ledgers compared at once, should not exceed ES max_result_window
+const gapsWindow = 10000
+
+// FillGapsCommand represents the `fill-gaps` CLI command
+type FillGapsCommand struct {
+	ES     es.Adapter
+	Source source.LedgerSource
+	Sink   sink.Sink
+	Config ExportCommandConfig
+}
+
+// Execute finds ledgers present in the source but missing in ES and exports them
+func (cmd *FillGapsCommand) Execute() {
+	exporter := &ExportCommand{Source: cmd.Source, Sink: cmd.Sink, Config: cmd.Config}
+	first, last := exporter.getRange()
+
+	log.Println("Searching for gaps from", first, "to", last)
+
+	missing := cmd.missingSeqs(first, last)
+
+	if len(missing) == 0 {
+		log.Println("No gaps found within given range!")
+		return
+	}
+
+	log.Println("Exporting", len(missing), "missing ledgers")
+
+	if cmd.Config.DryRun {
+		log.Println(missing)
+		return
+	}
+
+	createBar(len(missing))
+
+	for i := 0; i < len(missing); i += cmd.Config.BatchSize {
+		end := i + cmd.Config.BatchSize
+
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		seqs := missing[i:end]
+		exporter.submit(func() { exporter.exportLedgers(cmd.Source.LedgersForSeqs(seqs)) })
+	}
+
+	pool.StopWait()
+	finishBar()
+
+	cmd.Sink.Close()
+}
+
+// missingSeqs compares source and ES ledger seqnums window by window
+func (cmd *FillGapsCommand) missingSeqs(first, last int) (missing []int) {
+	for from := first; from <= last; from += gapsWindow {
+		to := from + gapsWindow - 1
+
+		if to > last {
+			to = last
+		}
+
+		indexed := make(map[int]bool)
+
+		for _, seq := range cmd.ES.GetLedgerSeqsInRange(from, to) {
+			indexed[seq] = true
+		}
+
+		for _, seq := range cmd.Source.LedgerSeqsInRange(from, to) {
+			if !indexed[seq] {
+				missing = append(missing, seq)
+			}
+		}
+	}
+
+	return missing
+}
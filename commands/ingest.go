@@ -1,78 +1,101 @@
 package commands
 
 import (
-	"bytes"
 	"log"
 	"time"
 
-	"github.com/astroband/astrologer/config"
-	"github.com/astroband/astrologer/db"
 	"github.com/astroband/astrologer/es"
+	"github.com/astroband/astrologer/sink"
+	"github.com/astroband/astrologer/source"
 )
 
-const ingestRetries = 25
+// IngestRetries is the number of bulk retries during ingestion
+const IngestRetries = 25
+
+// IngestCommandConfig represents configuration options for `ingest` CLI command
+type IngestCommandConfig struct {
+	Start      int
+	UseCursor  bool
+	CursorName string
+	RawXdr     bool
+
+	NetworkPassphrase string
+}
 
 // IngestCommand represents the CLI command which starts the Astrologer ingestion daemon
 type IngestCommand struct {
-	ES es.Adapter
-	DB db.Adapter
+	Source source.LedgerSource
+	Sink   sink.Sink
+	Config IngestCommandConfig
 }
 
 // Execute starts ingestion
 func (cmd *IngestCommand) Execute() {
 	current := cmd.getStartLedger()
-	log.Println("Starting ingest from", current.LedgerSeq)
+	log.Println("Starting ingest from", current.Seq())
 
 	for {
-		var b bytes.Buffer
-		var seq = current.LedgerSeq
-
-		txs := cmd.DB.TxHistoryRowForSeq(seq)
-		fees := cmd.DB.TxFeeHistoryRowsForRows(txs)
+		var seq = current.Seq()
 
-		err := es.SerializeLedger(*current, txs, fees, &b)
+		docs, err := es.ProduceDocuments(*current, es.ProduceOptions{
+			RawXdr:            cmd.Config.RawXdr,
+			NetworkPassphrase: cmd.Config.NetworkPassphrase,
+		})
 
 		if err != nil {
 			log.Fatalf("Failed to ingest ledger %d: %v\n", seq, err)
 		}
-		//es.NewBulkMaker(*current, txs, fees, &b).Make()
 
-		cmd.ES.IndexWithRetries(&b, ingestRetries)
+		cmd.Sink.Write(docs)
+		cmd.Sink.SaveCursor(cmd.Config.CursorName, seq)
 
 		log.Println("Ledger", seq, "ingested.")
 
-		current = cmd.DB.LedgerHeaderNext(seq)
+		current = cmd.waitNextLedger(seq)
+	}
+}
 
-		for {
-			if current != nil {
-				break
-			}
-			time.Sleep(1 * time.Second)
-			current = cmd.DB.LedgerHeaderNext(seq)
-		}
+// waitNextLedger polls the source until the ledger following seq appears
+func (cmd *IngestCommand) waitNextLedger(seq int) (l *source.LedgerCloseData) {
+	l = cmd.Source.NextLedger(seq)
+
+	for l == nil {
+		time.Sleep(1 * time.Second)
+		l = cmd.Source.NextLedger(seq)
 	}
+
+	return l
 }
 
-func (cmd *IngestCommand) getStartLedger() (h *db.LedgerHeaderRow) {
-	if *config.StartIngest == 0 {
-		h = cmd.DB.LedgerHeaderLastRow()
+func (cmd *IngestCommand) getStartLedger() (l *source.LedgerCloseData) {
+	if cmd.Config.UseCursor {
+		cursor := cmd.Sink.LoadCursor(cmd.Config.CursorName)
+
+		if cursor > 0 {
+			log.Println("Resuming from cursor", cmd.Config.CursorName, "at ledger", cursor)
+			return cmd.waitNextLedger(cursor)
+		}
+	}
+
+	if cmd.Config.Start > 0 {
+		l = cmd.Source.NextLedger(cmd.Config.Start)
 	} else {
-		if *config.StartIngest > 0 {
-			h = cmd.DB.LedgerHeaderNext(*config.StartIngest)
-		} else {
-			last := cmd.DB.LedgerHeaderLastRow()
+		last := cmd.Source.LastLedgerSeq()
 
-			if last == nil {
-				log.Fatal("Nothing to ingest")
-			}
+		if last == 0 {
+			log.Fatal("Nothing to ingest")
+		}
 
-			h = cmd.DB.LedgerHeaderNext(last.LedgerSeq + *config.StartIngest)
+		if cmd.Config.Start == 0 {
+			l = cmd.Source.NextLedger(last - 1)
+		} else {
+			l = cmd.Source.NextLedger(last + cmd.Config.Start)
 		}
 	}
 
-	if h == nil {
+	if l == nil {
 		log.Fatal("Nothing to ingest")
 	}
 
-	return h
+	return l
 }
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/astroband/astrologer/es"
+)
+
+// ingestIdleInterval is the time ingest cursor must stay still before documents are copied
+const ingestIdleInterval = 15 * time.Second
+
+// MigrateCommandConfig represents the configuration options for the `migrate` command
+type MigrateCommandConfig struct {
+	Indices    []string
+	DeleteOld  bool
+	Partition  es.Partitioning
+	CursorName string
+}
+
+// MigrateCommand represents the `migrate` CLI command
+type MigrateCommand struct {
+	ES     es.Adapter
+	Config MigrateCommandConfig
+
+	cursor      int
+	cursorCheck bool
+}
+
+// Execute moves indices to their current schema versions
+func (cmd *MigrateCommand) Execute() {
+	definitions := es.GetIndexDefinitions()
+	indices := cmd.ES.Indices()
+
+	if len(cmd.Config.Indices) == 0 {
+		for name := range definitions {
+			cmd.Config.Indices = append(cmd.Config.Indices, string(name))
+		}
+	}
+
+	for _, name := range cmd.Config.Indices {
+		def, ok := definitions[es.IndexName(name)]
+
+		if !ok {
+			log.Fatalf("Unknown index %s", name)
+		}
+
+		if cmd.Config.Partition.IsPartitioned(es.IndexName(name)) {
+			log.Printf("%s is partitioned, run create-index to update the template and export again to fill new partitions", name)
+			continue
+		}
+
+		alias := indices.Name(es.IndexName(name))
+		target := es.VersionedIndexName(alias, es.CurrentIndexVersion(es.IndexName(name)))
+
+		script := es.ReindexScript(es.IndexName(name))
+
+		cmd.migrate(alias, target, indices.Definition(es.IndexName(name), def), script)
+	}
+
+	fmt.Println("Indices migrated successfully!")
+}
+
+// migrate creates new index version, copies documents into it and swaps the alias. Renamed fields are
+// moved by the script while copying. Documents written to the old index while copying would be lost,
+// so it refuses to copy while ingestion is running.
+func (cmd *MigrateCommand) migrate(name es.IndexName, target es.IndexName, schema es.IndexDefinition, script string) {
+	current := cmd.ES.AliasTarget(name)
+
+	if current == target {
+		log.Printf("%s is up to date", name)
+		return
+	}
+
+	if current == "" && !cmd.ES.IndexExists(name) {
+		createIndexVersion(cmd.ES, target, schema, false)
+		cmd.ES.PutAlias(name, target)
+		log.Printf("%s index created as %s", name, target)
+		return
+	}
+
+	legacy := current == ""
+	if legacy {
+		current = name
+		log.Printf("%s is not versioned, it will be deleted after migration", name)
+	}
+
+	cmd.requireIngestStopped()
+
+	createIndexVersion(cmd.ES, target, schema, false)
+
+	log.Printf("Copying %s into %s", current, target)
+	cmd.ES.Reindex(current, target, script)
+
+	cmd.requireCursorUnchanged()
+	cmd.ES.SwapAlias(name, current, target)
+
+	log.Printf("%s now points to %s", name, target)
+
+	if cmd.Config.DeleteOld && !legacy {
+		cmd.ES.DeleteIndex(current)
+		log.Printf("%s deleted", current)
+	}
+}
+
+// requireIngestStopped stops if the ingest cursor advances, the check is done once per run
+func (cmd *MigrateCommand) requireIngestStopped() {
+	if cmd.cursorCheck {
+		cmd.requireCursorUnchanged()
+		return
+	}
+
+	cmd.cursor = cmd.ES.LoadCursor(cmd.Config.CursorName)
+	cmd.cursorCheck = true
+
+	log.Printf("Checking that ingest cursor %s does not advance for %s", cmd.Config.CursorName, ingestIdleInterval)
+	time.Sleep(ingestIdleInterval)
+
+	cmd.requireCursorUnchanged()
+}
+
+// requireCursorUnchanged stops if the ingest cursor moved since the check
+func (cmd *MigrateCommand) requireCursorUnchanged() {
+	if cursor := cmd.ES.LoadCursor(cmd.Config.CursorName); cursor != cmd.cursor {
+		log.Fatalf(
+			"Ingest cursor %s moved from %d to %d, stop ingestion and exports before migrate",
+			cmd.Config.CursorName, cmd.cursor, cursor,
+		)
+	}
+}
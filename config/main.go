@@ -50,11 +50,15 @@ func NumberWithSignParse(s kingpin.Settings) (target *NumberWithSign) {
 }
 
 var (
-	createIndexCommand = kingpin.Command("create-index", "Create ES indexes")
-	exportCommand      = kingpin.Command("export", "Run export")
-	ingestCommand      = kingpin.Command("ingest", "Start real time ingestion")
-	_                  = kingpin.Command("stats", "Print database ledger statistics")
-	_                  = kingpin.Command("es-stats", "Print ES ranges stats")
+	createIndexCommand   = kingpin.Command("create-index", "Create ES indexes")
+	createTablesCommand  = kingpin.Command("create-tables", "Create Postgres sink tables")
+	migrateCommand       = kingpin.Command("migrate", "Move ES indexes to current schema versions")
+	checkMappingsCommand = kingpin.Command("check-mappings", "Compare document fields with ES index mappings")
+	exportCommand        = kingpin.Command("export", "Run export")
+	ingestCommand        = kingpin.Command("ingest", "Start real time ingestion")
+	fillGapsCommand      = kingpin.Command("fill-gaps", "Export ledgers existing in database but missing in ES")
+	_                    = kingpin.Command("stats", "Print database ledger statistics")
+	_                    = kingpin.Command("es-stats", "Print ES ranges stats")
 
 	// DatabaseURL Stellar Core database URL
 	DatabaseURL = kingpin.
@@ -63,6 +67,34 @@ var (
 			OverrideDefaultFromEnvar("DATABASE_URL").
 			URL()
 
+	// Source Ledger source type
+	Source = kingpin.
+		Flag("source", "Ledger source: stellar-core database, history archive on disk or LedgerCloseMeta stream").
+		Default("database").
+		OverrideDefaultFromEnvar("SOURCE").
+		Enum("database", "archive", "meta")
+
+	// ArchiveURL Local path or file:// URL of the history archive
+	ArchiveURL = kingpin.
+			Flag("archive-url", "History archive path or file:// URL, used with --source=archive").
+			OverrideDefaultFromEnvar("ARCHIVE_URL").
+			String()
+
+	// MetaStream Path to LedgerCloseMeta stream file or named pipe
+	MetaStream = kingpin.
+			Flag("meta-stream", "LedgerCloseMeta stream file or named pipe, - means stdin, used with --source=meta").
+			Default("-").
+			OverrideDefaultFromEnvar("META_STREAM").
+			String()
+
+	// NetworkPassphrase Stellar network passphrase, required to match archived or streamed transactions with results
+	// and to hash inner transactions of fee bump envelopes
+	NetworkPassphrase = kingpin.
+				Flag("network-passphrase", "Stellar network passphrase").
+				Default("Public Global Stellar Network ; September 2015").
+				OverrideDefaultFromEnvar("NETWORK_PASSPHRASE").
+				String()
+
 	// EsURL ElasticSearch URL
 	EsURL = kingpin.
 		Flag("es-url", "ElasticSearch URL").
@@ -78,6 +110,72 @@ var (
 			OverrideDefaultFromEnvar("CONCURRENCY").
 			Int()
 
+	// BulkAction ES bulk action used to write documents
+	BulkAction = kingpin.
+			Flag("bulk-action", "Bulk action to write documents with: index overwrites existing ones, create skips them").
+			Default("index").
+			OverrideDefaultFromEnvar("BULK_ACTION").
+			Enum("index", "create")
+
+	// Sink Output backend type
+	Sink = kingpin.
+		Flag("sink", "Output backend: ElasticSearch, NDJSON files or Postgres tables").
+		Default("es").
+		OverrideDefaultFromEnvar("SINK").
+		Enum("es", "ndjson", "postgres")
+
+	// SinkDatabaseURL Postgres database URL to write tables to
+	SinkDatabaseURL = kingpin.
+			Flag("sink-database-url", "Postgres database URL to write tables to, used with --sink=postgres").
+			Default("postgres://localhost/astrologer?sslmode=disable").
+			OverrideDefaultFromEnvar("SINK_DATABASE_URL").
+			URL()
+
+	// NDJSONDir Directory for NDJSON files
+	NDJSONDir = kingpin.
+			Flag("ndjson-dir", "Directory to write NDJSON files to, used with --sink=ndjson").
+			Default("./export").
+			OverrideDefaultFromEnvar("NDJSON_DIR").
+			String()
+
+	// NDJSONRotate Number of ledgers per gzipped NDJSON file
+	NDJSONRotate = kingpin.
+			Flag("ndjson-rotate", "Split NDJSON files into gzipped files by ledger ranges of the given size, 0 means one plain file per index").
+			Default("0").
+			Int()
+
+	// RawXdr Store base64 XDR of transactions
+	RawXdr = kingpin.
+		Flag("raw-xdr", "Store base64 envelope, result, meta and fee meta XDR on transaction documents").
+		OverrideDefaultFromEnvar("RAW_XDR").
+		Bool()
+
+	// IndexPrefix Prefix of ES index names
+	IndexPrefix = kingpin.
+			Flag("index-prefix", "Prefix of ES index names, e.g. pubnet- to run several networks in the same cluster, overrides index config").
+			OverrideDefaultFromEnvar("INDEX_PREFIX").
+			String()
+
+	// IndexConfig Path to JSON file with index settings overrides
+	IndexConfig = kingpin.
+			Flag("index-config", "JSON file with index name prefix and per index shards, replicas and refresh interval").
+			OverrideDefaultFromEnvar("INDEX_CONFIG").
+			String()
+
+	// Partition Partitioning scheme of tx, op, balance and trades indices
+	Partition = kingpin.
+			Flag("partition", "Write tx, op, balance and trades into partitions by ledger close month or ledger range").
+			Default("none").
+			OverrideDefaultFromEnvar("PARTITION").
+			Enum("none", "monthly", "ledgers")
+
+	// PartitionLedgers Number of ledgers per partition
+	PartitionLedgers = kingpin.
+				Flag("partition-ledgers", "Ledger range size of a partition, used with --partition=ledgers").
+				Default("1000000").
+				OverrideDefaultFromEnvar("PARTITION_LEDGERS").
+				Int()
+
 	// BatchSize Batch size for bulk export
 	BatchSize = exportCommand.
 			Flag("batch", "Ledger batch size").
@@ -98,7 +196,20 @@ var (
 	Count = exportCommand.Arg("count", "Count of ledgers to ingest, should be aliquout batch size").Default("0").Int()
 
 	// StartIngest ledger to start with ingesting
-	StartIngest = ingestCommand.Arg("start", "Ledger to start ingesting").Int()
+	StartIngest = ingestCommand.Arg("start", "Ledger to start ingesting if there is no cursor, -100 means offset 100 from the last").Int()
+
+	// IngestUseCursor resume ingestion from the persisted cursor
+	IngestUseCursor = ingestCommand.
+			Flag("cursor", "Resume from the last ingested ledger stored in the sink, use --no-cursor to start from the given ledger").
+			Default("true").
+			Bool()
+
+	// IngestCursorName name of the persisted ingest cursor
+	IngestCursorName = ingestCommand.
+				Flag("cursor-name", "Name of the ingest cursor stored in the sink").
+				Default("ingest").
+				OverrideDefaultFromEnvar("INGEST_CURSOR_NAME").
+				String()
 
 	// Verbose print data
 	Verbose = exportCommand.Flag("verbose", "Print indexed data").Bool()
@@ -106,6 +217,47 @@ var (
 	// ExportDryRun do not index data
 	ExportDryRun = exportCommand.Flag("dry-run", "Do not send actual data to Elastic").Bool()
 
-	// ForceRecreateIndexes Allows indexes to be deleted before creation
-	ForceRecreateIndexes = createIndexCommand.Flag("force", "Delete indexes before creation").Bool()
+	// FillGapsStart ledger to start searching for gaps with
+	FillGapsStart = NumberWithSignParse(fillGapsCommand.Arg("start", "Ledger to start searching for gaps, +100 means offset 100 from the first"))
+
+	// FillGapsCount ledgers to search for gaps
+	FillGapsCount = fillGapsCommand.Arg("count", "Count of ledgers to search for gaps").Default("0").Int()
+
+	// FillGapsBatchSize Batch size for gaps export
+	FillGapsBatchSize = fillGapsCommand.
+				Flag("batch", "Ledger batch size").
+				Short('b').
+				Default("50").
+				Int()
+
+	// FillGapsRetries Number of retries
+	FillGapsRetries = fillGapsCommand.
+			Flag("retries", "Retries count").
+			Default("25").
+			Int()
+
+	// FillGapsDryRun do not index data
+	FillGapsDryRun = fillGapsCommand.Flag("dry-run", "Only report missing ledgers, do not send actual data to Elastic").Bool()
+
+	// ForceRecreateIndexes Allows physical indexes not behind aliases to be deleted before creation
+	ForceRecreateIndexes = createIndexCommand.Flag("force", "Recreate versioned indexes left by interrupted runs, live indexes are never deleted").Bool()
+
+	// MigrateIndexes Indexes to migrate
+	MigrateIndexes = migrateCommand.Arg("index", "Indexes to migrate, all by default").Strings()
+
+	// MigrateDeleteOld Delete previous index versions
+	MigrateDeleteOld = migrateCommand.Flag("delete-old", "Delete previous index versions after migration").Bool()
+
+	// MigrateCursorName name of the ingest cursor which must not advance during migration
+	MigrateCursorName = migrateCommand.
+				Flag("cursor-name", "Name of the ingest cursor, migrate refuses to run while it advances").
+				Default("ingest").
+				OverrideDefaultFromEnvar("INGEST_CURSOR_NAME").
+				String()
+
+	// CheckMappingsLive Compare documents with mappings of the live cluster as well
+	CheckMappingsLive = checkMappingsCommand.Flag("live", "Compare document fields with mappings of the live cluster as well").Bool()
+
+	// ForceRecreateTables Allows tables to be dropped before creation
+	ForceRecreateTables = createTablesCommand.Flag("force", "Drop tables before creation").Bool()
 )
@@ -2,8 +2,10 @@ package db
 
 import (
 	"database/sql"
+	"encoding/hex"
 	"log"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stellar/go/xdr"
 )
 
@@ -56,6 +58,45 @@ func (db *Client) LedgerHeaderRowFetchBatch(n, start, batchSize int) []LedgerHea
 	return ledgers
 }
 
+// LedgerHeaderRowsForSeqs returns ledgers with given seqnums
+func (db *Client) LedgerHeaderRowsForSeqs(seqs []int) []LedgerHeaderRow {
+	ledgers := []LedgerHeaderRow{}
+
+	if len(seqs) == 0 {
+		return ledgers
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM ledgerheaders WHERE ledgerseq IN (?) ORDER BY ledgerseq ASC", seqs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	query = db.rawClient.Rebind(query)
+	err = db.rawClient.Select(&ledgers, query, args...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return ledgers
+}
+
+// LedgerSeqsInRange returns seqnums of ledgers persisted in the database within given range
+func (db *Client) LedgerSeqsInRange(first, last int) []int {
+	seqs := []int{}
+
+	err := db.rawClient.Select(
+		&seqs,
+		"SELECT ledgerseq FROM ledgerheaders WHERE ledgerseq BETWEEN $1 AND $2 ORDER BY ledgerseq ASC",
+		first,
+		last)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return seqs
+}
+
 // LedgerHeaderLastRow returns lastest ledger in the database
 func (db *Client) LedgerHeaderLastRow() *LedgerHeaderRow {
 	var h LedgerHeaderRow
@@ -124,3 +165,18 @@ func (db *Client) LedgerHeaderGaps() (r []Gap) {
 
 	return r
 }
+
+// HeaderHistoryEntry returns XDR header history entry for the row
+func (row *LedgerHeaderRow) HeaderHistoryEntry() xdr.LedgerHeaderHistoryEntry {
+	var hash xdr.Hash
+
+	b, err := hex.DecodeString(row.Hash)
+
+	if err != nil {
+		log.Fatalf("Invalid hash of ledger %d: %v", row.LedgerSeq, err)
+	}
+
+	copy(hash[:], b)
+
+	return xdr.LedgerHeaderHistoryEntry{Hash: hash, Header: row.Data}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"log"
+
+	"github.com/astroband/astrologer/source"
+)
+
+// FirstLedgerSeq returns seqnum of the first ledger in the database, zero if database is empty
+func (db *Client) FirstLedgerSeq() int {
+	if row := db.LedgerHeaderFirstRow(); row != nil {
+		return row.LedgerSeq
+	}
+
+	return 0
+}
+
+// LastLedgerSeq returns seqnum of the last ledger in the database, zero if database is empty
+func (db *Client) LastLedgerSeq() int {
+	if row := db.LedgerHeaderLastRow(); row != nil {
+		return row.LedgerSeq
+	}
+
+	return 0
+}
+
+// LedgerCount returns total ledgers count within given range
+func (db *Client) LedgerCount(first, last int) int {
+	return db.LedgerHeaderRowCount(first, last)
+}
+
+// LedgerRange returns ledgers within given range along with their transactions
+func (db *Client) LedgerRange(first, last int) []source.LedgerCloseData {
+	rows := []LedgerHeaderRow{}
+
+	err := db.rawClient.Select(
+		&rows,
+		"SELECT * FROM ledgerheaders WHERE ledgerseq BETWEEN $1 AND $2 ORDER BY ledgerseq ASC",
+		first,
+		last)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return db.ledgerCloseData(rows)
+}
+
+// LedgersForSeqs returns ledgers with given seqnums along with their transactions
+func (db *Client) LedgersForSeqs(seqs []int) []source.LedgerCloseData {
+	return db.ledgerCloseData(db.LedgerHeaderRowsForSeqs(seqs))
+}
+
+// NextLedger returns the ledger following seq, nil if it is not closed yet
+func (db *Client) NextLedger(seq int) *source.LedgerCloseData {
+	row := db.LedgerHeaderNext(seq)
+
+	if row == nil {
+		return nil
+	}
+
+	return &db.ledgerCloseData([]LedgerHeaderRow{*row})[0]
+}
+
+// ledgerCloseData fetches transactions and fee changes for the given ledger rows
+func (db *Client) ledgerCloseData(rows []LedgerHeaderRow) []source.LedgerCloseData {
+	seqs := make([]int, len(rows))
+
+	for n, row := range rows {
+		seqs[n] = row.LedgerSeq
+	}
+
+	txs := db.TxHistoryRowsForSeqs(seqs)
+	fees := make(map[int]map[int]TxFeeHistoryRow)
+
+	for _, fee := range db.TxFeeHistoryRowsForSeqs(seqs) {
+		if fees[fee.LedgerSeq] == nil {
+			fees[fee.LedgerSeq] = make(map[int]TxFeeHistoryRow)
+		}
+
+		fees[fee.LedgerSeq][fee.Index] = fee
+	}
+
+	transactions := make(map[int][]source.Transaction)
+
+	for _, tx := range txs {
+		transactions[tx.LedgerSeq] = append(transactions[tx.LedgerSeq], source.Transaction{
+			Hash:       tx.ID,
+			Index:      tx.Index,
+			Envelope:   tx.Envelope,
+			Result:     tx.Result,
+			Meta:       tx.Meta,
+			FeeChanges: fees[tx.LedgerSeq][tx.Index].Changes,
+		})
+	}
+
+	ledgers := make([]source.LedgerCloseData, len(rows))
+
+	for n, row := range rows {
+		ledgers[n] = source.LedgerCloseData{
+			Header:       row.HeaderHistoryEntry(),
+			Transactions: transactions[row.LedgerSeq],
+		}
+	}
+
+	return ledgers
+}
@@ -15,21 +15,15 @@ type TxFeeHistoryRow struct {
 	Changes   xdr.LedgerEntryChanges `db:"txchanges"`
 }
 
-// TxFeeHistoryRowsForRows returns transactions for specified ledger sorted by index
-func (db *Client) TxFeeHistoryRowsForRows(rows []TxHistoryRow) []TxFeeHistoryRow {
+// TxFeeHistoryRowsForSeqs returns fee changes for specified ledgers sorted by ledger and index
+func (db *Client) TxFeeHistoryRowsForSeqs(seqs []int) []TxFeeHistoryRow {
 	txs := []TxFeeHistoryRow{}
 
-	if len(rows) == 0 {
+	if len(seqs) == 0 {
 		return txs
 	}
 
-	ids := make([]int, len(rows))
-
-	for n := 0; n < len(rows); n++ {
-		ids[n] = rows[n].LedgerSeq
-	}
-
-	query, args, err := sqlx.In("SELECT * FROM txfeehistory WHERE ledgerseq IN (?) ORDER BY ledgerseq, txindex", ids)
+	query, args, err := sqlx.In("SELECT * FROM txfeehistory WHERE ledgerseq IN (?) ORDER BY ledgerseq, txindex", seqs)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,12 +1,9 @@
 package db
 
 import (
-	"encoding/base64"
-	"fmt"
 	"log"
-	"strings"
 
-	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
 	"github.com/stellar/go/xdr"
 )
 
@@ -32,76 +29,24 @@ func (db *Client) TxHistoryRowForSeq(seq int) []TxHistoryRow {
 	return txs
 }
 
-// MemoValue Returns clean memo value, this is copy paste from horizon internal package
-func (tx *TxHistoryRow) MemoValue() null.String {
-	var (
-		value string
-		valid bool
-		memo  = tx.Envelope.Memo()
-	)
-
-	switch memo.Type {
-	case xdr.MemoTypeMemoNone:
-		value, valid = "", false
-	case xdr.MemoTypeMemoText:
-		scrubbed := utf8Scrub(memo.MustText())
-		notnull := strings.Join(strings.Split(scrubbed, "\x00"), "")
-		value, valid = notnull, true
-	case xdr.MemoTypeMemoId:
-		value, valid = fmt.Sprintf("%d", memo.MustId()), true
-	case xdr.MemoTypeMemoHash:
-		hash := memo.MustHash()
-		value, valid =
-			base64.StdEncoding.EncodeToString(hash[:]),
-			true
-	case xdr.MemoTypeMemoReturn:
-		hash := memo.MustRetHash()
-		value, valid =
-			base64.StdEncoding.EncodeToString(hash[:]),
-			true
-	default:
-		panic(fmt.Errorf("invalid memo type: %v", memo.Type))
-	}
-
-	return null.NewString(value, valid)
-}
-
-// Operations returns operations array
-func (tx *TxHistoryRow) Operations() (error, []xdr.Operation) {
-	switch tx.Envelope.Type {
-	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
-		return nil, tx.Envelope.V0.Tx.Operations
-	case xdr.EnvelopeTypeEnvelopeTypeTx:
-		return nil, tx.Envelope.V1.Tx.Operations
-	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
-		return nil, tx.Envelope.FeeBump.Tx.InnerTx.V1.Tx.Operations
-	default:
-		return fmt.Errorf("Unknown tx envelope type %s", tx.Envelope.Type), make([]xdr.Operation, 0)
-	}
-}
-
-// ResultFor returns result for operation index
-func (tx *TxHistoryRow) ResultFor(index int) (result *xdr.OperationResult) {
-	results := tx.Result.Result.Result.Results
+// TxHistoryRowsForSeqs returns transactions for specified ledgers sorted by ledger and index
+func (db *Client) TxHistoryRowsForSeqs(seqs []int) []TxHistoryRow {
+	txs := []TxHistoryRow{}
 
-	if results != nil {
-		result = &(*results)[index]
+	if len(seqs) == 0 {
+		return txs
 	}
 
-	return result
-}
-
-// MetasFor returns meta for operation index
-func (tx *TxHistoryRow) MetasFor(index int) (result *xdr.OperationMeta) {
-	if v1, ok := tx.Meta.GetV1(); ok {
-		ops := v1.Operations
-		return &ops[index]
+	query, args, err := sqlx.In("SELECT * FROM txhistory WHERE ledgerseq IN (?) ORDER BY ledgerseq, txindex", seqs)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	ops, ok := tx.Meta.GetOperations()
-	if !ok {
-		return nil
+	query = db.rawClient.Rebind(query)
+	err = db.rawClient.Select(&txs, query, args...)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	return &ops[index]
+	return txs
 }
@@ -0,0 +1,73 @@
+package es
+
+import (
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+)
+
+// Account represents the latest state of an account
+type Account struct {
+	ID                 string             `json:"id"`
+	Balance            string             `json:"balance,omitempty"`
+	SeqNum             int64              `json:"seq_num,omitempty"`
+	NumSubentries      int                `json:"num_subentries"`
+	InflationDest      string             `json:"inflation_dest_id,omitempty"`
+	HomeDomain         string             `json:"home_domain,omitempty"`
+	Flags              *AccountFlags      `json:"flags,omitempty"`
+	Thresholds         *AccountThresholds `json:"thresholds,omitempty"`
+	Signers            []*Signer          `json:"signers,omitempty"`
+	LastModifiedLedger int                `json:"last_modified_ledger"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	Removed            bool               `json:"removed,omitempty"`
+}
+
+// NewAccount creates Account from AccountEntry
+func NewAccount(a xdr.AccountEntry, seq int, closeTime time.Time) *Account {
+	flags := xdr.Uint32(a.Flags)
+
+	account := &Account{
+		ID:                 a.AccountId.Address(),
+		Balance:            amount.String(a.Balance),
+		SeqNum:             int64(a.SeqNum),
+		NumSubentries:      int(a.NumSubEntries),
+		InflationDest:      inflationDest(a),
+		HomeDomain:         string(a.HomeDomain),
+		Flags:              NewAccountFlags(&flags),
+		Thresholds:         NewAccountThresholdsFromXdr(a.Thresholds),
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+	}
+
+	for i := range a.Signers {
+		account.Signers = append(account.Signers, NewSigner(&a.Signers[i]))
+	}
+
+	return account
+}
+
+// NewRemovedAccount creates Account representing merged account
+func NewRemovedAccount(id string, seq int, closeTime time.Time) *Account {
+	return &Account{ID: id, LastModifiedLedger: seq, UpdatedAt: closeTime, Removed: true}
+}
+
+// DocID returns account id
+func (a *Account) DocID() *string {
+	return &a.ID
+}
+
+// IndexName accounts index name
+func (a *Account) IndexName() IndexName {
+	return accountsIndexName
+}
+
+// StateSeq returns ledger seq account state was taken at
+func (a *Account) StateSeq() int {
+	return a.LastModifiedLedger
+}
+
+// IsRemoved returns true if account was merged
+func (a *Account) IsRemoved() bool {
+	return a.Removed
+}
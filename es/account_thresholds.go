@@ -40,3 +40,13 @@ func NewAccountThresholds(low *xdr.Uint32, medium *xdr.Uint32, high *xdr.Uint32,
 
 	return thresholds
 }
+
+// NewAccountThresholdsFromXdr returns thresholds of the account entry
+func NewAccountThresholdsFromXdr(t xdr.Thresholds) *AccountThresholds {
+	low := xdr.Uint32(t.ThresholdLow())
+	medium := xdr.Uint32(t.ThresholdMedium())
+	high := xdr.Uint32(t.ThresholdHigh())
+	master := xdr.Uint32(t.MasterKeyWeight())
+
+	return NewAccountThresholds(&low, &medium, &high, &master)
+}
@@ -2,9 +2,9 @@ package es
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log"
-	"math/rand"
 	"net/http"
 	"strings"
 	"time"
@@ -41,6 +41,77 @@ func (es *Client) CreateIndex(name IndexName, body IndexDefinition) {
 	fatalIfError(res, err)
 }
 
+// PutTemplate creates or replaces index template, existing indices are not affected
+func (es *Client) PutTemplate(name IndexName, body IndexDefinition) {
+	req := esapi.IndicesPutTemplateRequest{
+		Name: string(name),
+		Body: strings.NewReader(string(body)),
+	}
+
+	res, err := req.Do(context.Background(), es.rawClient)
+	fatalIfError(res, err)
+	res.Body.Close()
+}
+
+// GetMappings returns live mappings of every physical index behind the name, empty if there is none
+func (es *Client) GetMappings(name IndexName) map[IndexName]IndexDefinition {
+	var r map[IndexName]json.RawMessage
+
+	res, err := es.rawClient.Indices.GetMapping(es.rawClient.Indices.GetMapping.WithIndex(string(name)))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	fatalIfError(res, err)
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	mappings := make(map[IndexName]IndexDefinition, len(r))
+	for index, body := range r {
+		mappings[index] = IndexDefinition(body)
+	}
+
+	return mappings
+}
+
+// GetTemplate returns the index template with a given name, exists is false if there is no such template
+func (es *Client) GetTemplate(name IndexName) (body IndexDefinition, exists bool) {
+	var r map[IndexName]json.RawMessage
+
+	req := esapi.IndicesGetTemplateRequest{Name: []string{string(name)}}
+
+	res, err := req.Do(context.Background(), es.rawClient)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return "", false
+	}
+
+	fatalIfError(res, err)
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	raw, exists := r[name]
+
+	return IndexDefinition(raw), exists
+}
+
 func (es *Client) searchLedgers(query map[string]interface{}) (r map[string]interface{}) {
 	var buf bytes.Buffer
 
@@ -49,7 +120,7 @@ func (es *Client) searchLedgers(query map[string]interface{}) (r map[string]inte
 	}
 
 	res, err := es.rawClient.Search(
-		es.rawClient.Search.WithIndex("ledger"),
+		es.rawClient.Search.WithIndex(string(es.indices.Name(ledgerHeaderIndexName))),
 		es.rawClient.Search.WithBody(&buf),
 	)
 
@@ -105,15 +176,31 @@ func (es *Client) LedgerSeqRangeQuery(ranges []map[string]interface{}) map[strin
 	return aggs
 }
 
-// BulkInsert sends the payload to ES using bulk operation
-func (es *Client) BulkInsert(payload *bytes.Buffer) (success bool) {
+// BulkInsert sends the payload to ES using bulk operation. It returns the items
+// failed with retryable status, success is false if the whole request has failed.
+func (es *Client) BulkInsert(payload *bytes.Buffer) (retry *bytes.Buffer, success bool) {
+	var r bulkResponse
+
 	res, err := es.rawClient.Bulk(bytes.NewReader(payload.Bytes()))
 
-	if res != nil {
-		defer res.Body.Close()
+	if err != nil {
+		log.Println(err)
+		return nil, false
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		log.Println("Error in bulk response", res.Status())
+		return nil, false
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Printf("Error parsing the bulk response body: %s", err)
+		return nil, false
 	}
 
-	return err == nil && (res == nil || !res.IsError())
+	return es.processBulkResponse(payload, &r)
 }
 
 // LedgerCountInRange counts number of ledgers from the given range persisted into ES
@@ -137,7 +224,7 @@ func (es *Client) LedgerCountInRange(min, max int) int {
 	}
 
 	res, err := es.rawClient.Count(
-		es.rawClient.Count.WithIndex("ledger"),
+		es.rawClient.Count.WithIndex(string(es.indices.Name(ledgerHeaderIndexName))),
 		es.rawClient.Count.WithBody(&buf),
 	)
 
@@ -152,7 +239,7 @@ func (es *Client) LedgerCountInRange(min, max int) int {
 	return int(r["count"].(float64))
 }
 
-// GetLedgerSeqsInRange rerutns seqnums of ledgers from the given range persisted in the ES cluster
+// GetLedgerSeqsInRange returns seqnums of ledgers from the given range (inclusive) persisted in the ES cluster
 func (es *Client) GetLedgerSeqsInRange(min, max int) (seqs []int) {
 	query := map[string]interface{}{
 		"_source": []string{"seq"},
@@ -164,7 +251,7 @@ func (es *Client) GetLedgerSeqsInRange(min, max int) (seqs []int) {
 			"range": map[string]interface{}{
 				"seq": map[string]interface{}{
 					"gte": min,
-					"lt":  max,
+					"lte": max,
 				},
 			},
 		},
@@ -181,19 +268,25 @@ func (es *Client) GetLedgerSeqsInRange(min, max int) (seqs []int) {
 	return
 }
 
-// IndexWithRetries performs a bulk insert into ES cluster with retries on failures
+// IndexWithRetries performs a bulk insert into ES cluster, resending failed requests
+// and retryable items alone with backoff
 func (es *Client) IndexWithRetries(payload *bytes.Buffer, retryCount int) {
-	isIndexed := es.BulkInsert(payload)
+	for attempt := 1; payload.Len() > 0; attempt++ {
+		retry, success := es.BulkInsert(payload)
 
-	if !isIndexed {
-		if retryCount-1 == 0 {
-			log.Fatal("Retries for bulk failed, aborting")
+		if success {
+			payload = retry
 		}
 
-		delay := time.Duration((rand.Intn(10) + 5))
-		time.Sleep(delay * time.Second)
+		if payload.Len() == 0 {
+			return
+		}
+
+		if attempt >= retryCount {
+			log.Fatal("Retries for bulk failed, aborting")
+		}
 
-		es.IndexWithRetries(payload, retryCount-1)
+		time.Sleep(retryDelay(attempt))
 	}
 }
 
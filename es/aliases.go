@@ -0,0 +1,222 @@
+package es
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// indexVersions holds schema versions of indices, bump the version along with any mapping change
+// and run `migrate` to move the data into the new physical index
+var indexVersions = map[IndexName]int{
+	ledgerHeaderIndexName:     2,
+	txIndexName:               1,
+	opIndexName:               2,
+	balanceIndexName:          1,
+	tradesIndexName:           2,
+	signerHistoryIndexName:    1,
+	stateIndexName:            1,
+	effectsIndexName:          1,
+	accountsIndexName:         2,
+	offersIndexName:           2,
+	trustlinesIndexName:       2,
+	dataEntriesIndexName:      2,
+	inflationPayoutsIndexName: 1,
+}
+
+// reindexScripts rewrite documents written by previous schema versions while they are copied
+var reindexScripts = map[IndexName]string{
+	accountsIndexName:     dropRemovedScript,
+	offersIndexName:       dropRemovedScript,
+	trustlinesIndexName:   dropRemovedScript,
+	dataEntriesIndexName:  dropRemovedScript,
+	ledgerHeaderIndexName: renameFieldsScript(map[string]string{"max_tx_size": "max_tx_set_size"}),
+}
+
+// dropRemovedScript skips tombstones of removed state entries written by previous schema versions
+const dropRemovedScript = "if (ctx._source.removed == true) { ctx.op = 'noop' } "
+
+// renameFieldsScript returns painless script moving values of old fields into new ones
+func renameFieldsScript(fields map[string]string) string {
+	var script strings.Builder
+
+	for from, to := range fields {
+		fmt.Fprintf(
+			&script,
+			"if (ctx._source.containsKey('%s')) { ctx._source['%s'] = ctx._source.remove('%s') } ",
+			from, to, from,
+		)
+	}
+
+	return script.String()
+}
+
+// reindexPollInterval is the interval reindex task status is checked with
+const reindexPollInterval = 10 * time.Second
+
+// CurrentIndexVersion returns the schema version of the index
+func CurrentIndexVersion(name IndexName) int {
+	if v, ok := indexVersions[name]; ok {
+		return v
+	}
+
+	return 1
+}
+
+// VersionedIndexName returns the name of physical index holding the given schema version, e.g. op-v3
+func VersionedIndexName(name IndexName, version int) IndexName {
+	return IndexName(fmt.Sprintf("%s-v%d", name, version))
+}
+
+// AliasTarget returns the physical index the alias points to, empty string if there is no such alias
+func (es *Client) AliasTarget(alias IndexName) IndexName {
+	var r map[string]interface{}
+
+	res, err := es.rawClient.Indices.GetAlias(es.rawClient.Indices.GetAlias.WithName(string(alias)))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ""
+	}
+
+	fatalIfError(res, err)
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	for index := range r {
+		return IndexName(index)
+	}
+
+	return ""
+}
+
+// PutAlias points the alias to the index
+func (es *Client) PutAlias(alias IndexName, index IndexName) {
+	res, err := es.rawClient.Indices.PutAlias([]string{string(index)}, string(alias))
+	fatalIfError(res, err)
+	res.Body.Close()
+}
+
+// SwapAlias atomically moves the alias from one index to another. If from is a concrete index
+// having the alias name (not versioned index), it is deleted in the same request.
+func (es *Client) SwapAlias(alias IndexName, from IndexName, to IndexName) {
+	remove := map[string]interface{}{
+		"remove": map[string]interface{}{"index": from, "alias": alias},
+	}
+
+	if from == alias {
+		remove = map[string]interface{}{
+			"remove_index": map[string]interface{}{"index": from},
+		}
+	}
+
+	body := map[string]interface{}{
+		"actions": []map[string]interface{}{
+			remove,
+			{"add": map[string]interface{}{"index": to, "alias": alias}},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		log.Fatalf("Error encoding aliases: %s", err)
+	}
+
+	res, err := es.rawClient.Indices.UpdateAliases(&buf)
+	fatalIfError(res, err)
+	res.Body.Close()
+}
+
+// ReindexScript returns the script applied to documents of the index during migration, empty if none
+func ReindexScript(name IndexName) string {
+	return reindexScripts[name]
+}
+
+// Reindex copies all documents from one index to another applying the script, if any, and waits for
+// completion. Documents are copied with external versions, so state documents keep ledger seqs they
+// are versioned by. Nothing must be written to the source while it is copied.
+func (es *Client) Reindex(from IndexName, to IndexName, script string) {
+	var r struct {
+		Task string `json:"task"`
+	}
+
+	body := map[string]interface{}{
+		"conflicts": "proceed",
+		"source":    map[string]interface{}{"index": from},
+		"dest":      map[string]interface{}{"index": to, "version_type": "external"},
+	}
+
+	if script != "" {
+		body["script"] = map[string]interface{}{"source": script, "lang": "painless"}
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		log.Fatalf("Error encoding reindex request: %s", err)
+	}
+
+	res, err := es.rawClient.Reindex(&buf, es.rawClient.Reindex.WithWaitForCompletion(false))
+	fatalIfError(res, err)
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	res.Body.Close()
+
+	es.waitTask(r.Task)
+}
+
+// waitTask polls the task until it is completed, reporting the progress
+func (es *Client) waitTask(id string) {
+	for {
+		var r struct {
+			Completed bool `json:"completed"`
+			Task      struct {
+				Status struct {
+					Total   int `json:"total"`
+					Created int `json:"created"`
+					Updated int `json:"updated"`
+				} `json:"status"`
+			} `json:"task"`
+			Response struct {
+				Failures []interface{} `json:"failures"`
+			} `json:"response"`
+		}
+
+		res, err := es.rawClient.Tasks.Get(id)
+		fatalIfError(res, err)
+
+		if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+			log.Fatalf("Error parsing the response body: %s", err)
+		}
+
+		res.Body.Close()
+
+		status := r.Task.Status
+		log.Printf("Reindexed %d of %d documents", status.Created+status.Updated, status.Total)
+
+		if r.Completed {
+			if len(r.Response.Failures) > 0 {
+				log.Fatalf("Reindex failed: %v", r.Response.Failures)
+			}
+
+			return
+		}
+
+		time.Sleep(reindexPollInterval)
+	}
+}
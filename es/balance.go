@@ -24,38 +24,84 @@ type Balance struct {
 	PagingToken PagingToken   `json:"paging_token"`
 	AccountID   string        `json:"account_id"`
 	Value       string        `json:"value"`
+	PrevValue   string        `json:"prev_value"`
 	Diff        string        `json:"diff"`
 	Positive    bool          `json:"positive"`
+	Removed     bool          `json:"removed,omitempty"`
 	CreatedAt   time.Time     `json:"created_at"`
 	Source      BalanceSource `json:"source"`
 	Asset       Asset         `json:"asset"`
+
+	BuyingLiabilities  string `json:"buying_liabilities"`
+	SellingLiabilities string `json:"selling_liabilities"`
+	MinimumReserve     string `json:"minimum_reserve"`
+	Available          string `json:"available"`
+}
+
+// NewBalanceFromAccountEntry creates Balance from AccountEntry and previous balance,
+// minimum reserve is calculated from the ledger base reserve and account subentries
+func NewBalanceFromAccountEntry(a xdr.AccountEntry, prev xdr.Int64, baseReserve int, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
+	b := newBalance(a.AccountId.Address(), *NewNativeAsset(), a.Balance, prev, now, pagingToken, source)
+	reserve := xdr.Int64(2+int64(a.NumSubEntries)) * xdr.Int64(baseReserve)
+
+	if v1, ok := a.Ext.GetV1(); ok {
+		b.setLiabilities(a.Balance, v1.Liabilities, reserve)
+	} else {
+		b.setLiabilities(a.Balance, xdr.Liabilities{}, reserve)
+	}
+
+	return b
 }
 
-// NewBalanceFromAccountEntry creates Balance from AccountEntry
-func NewBalanceFromAccountEntry(a xdr.AccountEntry, diff xdr.Int64, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
+// NewBalanceFromTrustLineEntry creates Balance from TrustLineEntry and previous balance
+func NewBalanceFromTrustLineEntry(t xdr.TrustLineEntry, prev xdr.Int64, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
+	b := newBalance(t.AccountId.Address(), *NewAsset(&t.Asset), t.Balance, prev, now, pagingToken, source)
+
+	if v1, ok := t.Ext.GetV1(); ok {
+		b.setLiabilities(t.Balance, v1.Liabilities, 0)
+	} else {
+		b.setLiabilities(t.Balance, xdr.Liabilities{}, 0)
+	}
+
+	return b
+}
+
+// NewClosingBalance creates zero Balance for merged account or removed trustline
+func NewClosingBalance(accountID string, asset Asset, prev xdr.Int64, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
+	b := newBalance(accountID, asset, 0, prev, now, pagingToken, source)
+	b.setLiabilities(0, xdr.Liabilities{}, 0)
+	b.Removed = true
+
+	return b
+}
+
+func newBalance(accountID string, asset Asset, value xdr.Int64, prev xdr.Int64, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
+	diff := value - prev
+
 	return &Balance{
 		PagingToken: pagingToken,
-		AccountID:   a.AccountId.Address(),
-		Value:       amount.String(a.Balance),
+		AccountID:   accountID,
+		Value:       amount.String(value),
+		PrevValue:   amount.String(prev),
 		Diff:        amount.String(diff),
 		Positive:    diff > 0,
 		Source:      source,
 		CreatedAt:   now,
-		Asset:       *NewNativeAsset(),
+		Asset:       asset,
 	}
 }
 
-// NewBalanceFromTrustLineEntry creates Balance from TrustLineEntry
-func NewBalanceFromTrustLineEntry(t xdr.TrustLineEntry, diff xdr.Int64, now time.Time, pagingToken PagingToken, source BalanceSource) *Balance {
-	return &Balance{
-		PagingToken: pagingToken,
-		AccountID:   t.AccountId.Address(),
-		Value:       amount.String(t.Balance),
-		Diff:        amount.String(diff),
-		Source:      source,
-		CreatedAt:   now,
-		Asset:       *NewAsset(&t.Asset),
+// setLiabilities sets liabilities, reserve and the balance available to send or sell
+func (b *Balance) setLiabilities(value xdr.Int64, l xdr.Liabilities, reserve xdr.Int64) {
+	available := value - reserve - l.Selling
+	if available < 0 {
+		available = 0
 	}
+
+	b.BuyingLiabilities = amount.String(l.Buying)
+	b.SellingLiabilities = amount.String(l.Selling)
+	b.MinimumReserve = amount.String(reserve)
+	b.Available = amount.String(available)
 }
 
 // DocID balance es document id
@@ -68,3 +114,8 @@ func (b *Balance) DocID() *string {
 func (b *Balance) IndexName() IndexName {
 	return balanceIndexName
 }
+
+// PartitionKey returns close time and ledger seq the balance partition is selected by
+func (b *Balance) PartitionKey() (time.Time, int) {
+	return b.CreatedAt, b.PagingToken.LedgerSeq
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
-// AccountBalanceMap is account id <=> balance map
+// AccountBalanceMap is account id and asset id <=> balance map
 type accountBalanceMap map[string]xdr.Int64
 
 // BalanceExtractor is temporary struct holding data essential for processing the set of changes
@@ -15,19 +15,21 @@ type BalanceExtractor struct {
 	closeTime       time.Time
 	source          BalanceSource
 	basePagingToken PagingToken
+	baseReserve     int
 
 	values   accountBalanceMap
 	balances []*Balance
 	index    int
 }
 
-// ProduceBalances constructs balance extracotr and returns balances
-func ProduceBalances(changes []xdr.LedgerEntryChange, t time.Time, source BalanceSource, basePagingToken PagingToken) (balances []*Balance) {
+// ProduceBalances constructs balance extracotr and returns balances, base reserve is taken from the ledger header
+func ProduceBalances(changes []xdr.LedgerEntryChange, t time.Time, source BalanceSource, basePagingToken PagingToken, baseReserve int) (balances []*Balance) {
 	e := &BalanceExtractor{
 		changes:         changes,
 		closeTime:       t,
 		source:          source,
 		basePagingToken: basePagingToken,
+		baseReserve:     baseReserve,
 		values:          make(accountBalanceMap),
 		index:           0,
 	}
@@ -51,6 +53,9 @@ func (e *BalanceExtractor) extract() []*Balance {
 
 		case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
 			e.updated(change)
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			e.removed(change)
 		}
 	}
 
@@ -67,13 +72,10 @@ func (e *BalanceExtractor) state(change xdr.LedgerEntryChange) {
 	switch x := state.Type; x {
 	case xdr.LedgerEntryTypeAccount:
 		account := state.MustAccount()
-		address := account.AccountId.Address()
-
-		e.values[address] = account.Balance
+		e.values[balanceKey(account.AccountId, NewNativeAsset())] = account.Balance
 	case xdr.LedgerEntryTypeTrustline:
 		line := state.MustTrustLine()
-		address := line.AccountId.Address()
-		e.values[address] = line.Balance
+		e.values[balanceKey(line.AccountId, NewAsset(&line.Asset))] = line.Balance
 	}
 }
 
@@ -88,14 +90,14 @@ func (e *BalanceExtractor) created(change xdr.LedgerEntryChange) {
 
 		e.balances = append(
 			e.balances,
-			NewBalanceFromAccountEntry(account, account.Balance, e.closeTime, pagingToken, e.source),
+			NewBalanceFromAccountEntry(account, 0, e.baseReserve, e.closeTime, pagingToken, e.source),
 		)
 	case xdr.LedgerEntryTypeTrustline:
 		line := created.MustTrustLine()
 
 		e.balances = append(
 			e.balances,
-			NewBalanceFromTrustLineEntry(line, line.Balance, e.closeTime, pagingToken, e.source),
+			NewBalanceFromTrustLineEntry(line, 0, e.closeTime, pagingToken, e.source),
 		)
 	}
 }
@@ -106,33 +108,68 @@ func (e *BalanceExtractor) updated(change xdr.LedgerEntryChange) {
 	switch x := updated.Type; x {
 	case xdr.LedgerEntryTypeAccount:
 		account := updated.MustAccount()
-		address := account.AccountId.Address()
-		oldBalance := e.values[address]
+		key := balanceKey(account.AccountId, NewNativeAsset())
+		oldBalance := e.values[key]
+		e.values[key] = account.Balance
 
 		if oldBalance != account.Balance {
-			diff := account.Balance - oldBalance
 			e.index++
 			pagingToken := PagingToken{EffectIndex: e.index}.Merge(e.basePagingToken)
 
 			e.balances = append(
 				e.balances,
-				NewBalanceFromAccountEntry(account, diff, e.closeTime, pagingToken, e.source),
+				NewBalanceFromAccountEntry(account, oldBalance, e.baseReserve, e.closeTime, pagingToken, e.source),
 			)
 		}
 	case xdr.LedgerEntryTypeTrustline:
 		line := updated.MustTrustLine()
-		address := line.AccountId.Address()
-		oldBalance := e.values[address]
+		key := balanceKey(line.AccountId, NewAsset(&line.Asset))
+		oldBalance := e.values[key]
+		e.values[key] = line.Balance
 
 		if oldBalance != line.Balance {
-			diff := line.Balance - oldBalance
 			e.index++
 			pagingToken := PagingToken{EffectIndex: e.index}.Merge(e.basePagingToken)
 
 			e.balances = append(
 				e.balances,
-				NewBalanceFromTrustLineEntry(line, diff, e.closeTime, pagingToken, e.source),
+				NewBalanceFromTrustLineEntry(line, oldBalance, e.closeTime, pagingToken, e.source),
 			)
 		}
 	}
 }
+
+func (e *BalanceExtractor) removed(change xdr.LedgerEntryChange) {
+	removed := change.MustRemoved()
+
+	var accountID xdr.AccountId
+	var asset *Asset
+
+	switch x := removed.Type; x {
+	case xdr.LedgerEntryTypeAccount:
+		accountID = removed.MustAccount().AccountId
+		asset = NewNativeAsset()
+	case xdr.LedgerEntryTypeTrustline:
+		line := removed.MustTrustLine()
+		accountID = line.AccountId
+		asset = NewAsset(&line.Asset)
+	default:
+		return
+	}
+
+	key := balanceKey(accountID, asset)
+	oldBalance := e.values[key]
+	delete(e.values, key)
+
+	e.index++
+	pagingToken := PagingToken{EffectIndex: e.index}.Merge(e.basePagingToken)
+
+	e.balances = append(
+		e.balances,
+		NewClosingBalance(accountID.Address(), *asset, oldBalance, e.closeTime, pagingToken, e.source),
+	)
+}
+
+func balanceKey(accountID xdr.AccountId, asset *Asset) string {
+	return accountID.Address() + "-" + asset.ID
+}
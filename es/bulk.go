@@ -0,0 +1,196 @@
+package es
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BulkStats represents the summary of documents sent to ElasticSearch during the run
+type BulkStats struct {
+	Indexed int
+	Skipped int
+	Dropped map[IndexName]int
+}
+
+// TotalDropped returns the number of documents dropped from all indices
+func (s BulkStats) TotalDropped() (total int) {
+	for _, count := range s.Dropped {
+		total += count
+	}
+
+	return total
+}
+
+// bulkResponse represents ElasticSearch bulk API response
+type bulkResponse struct {
+	Errors bool                          `json:"errors"`
+	Items  []map[string]bulkResponseItem `json:"items"`
+}
+
+// bulkResponseItem represents the result of a single bulk action, keyed by action name in the response
+type bulkResponseItem struct {
+	Index  string             `json:"_index"`
+	ID     string             `json:"_id"`
+	Status int                `json:"status"`
+	Error  *bulkResponseError `json:"error"`
+}
+
+type bulkResponseError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// bulkItem represents a single action of the bulk payload along with its document
+type bulkItem struct {
+	meta   []byte
+	source []byte
+}
+
+func (i bulkItem) writeTo(b *bytes.Buffer) {
+	b.Write(i.meta)
+	b.WriteByte('\n')
+
+	if i.source != nil {
+		b.Write(i.source)
+		b.WriteByte('\n')
+	}
+}
+
+// ledgerSeq returns ledger seq of the document extracted from its paging token
+func (i bulkItem) ledgerSeq() int {
+	var doc struct {
+		PagingToken string `json:"paging_token"`
+	}
+
+	if err := json.Unmarshal(i.source, &doc); err != nil {
+		return 0
+	}
+
+	seq, _ := strconv.Atoi(strings.SplitN(doc.PagingToken, "-", 2)[0])
+	return seq
+}
+
+// splitBulkPayload splits bulk payload into separate actions, delete actions have no document line
+func splitBulkPayload(payload []byte) (items []bulkItem) {
+	lines := bytes.Split(bytes.TrimRight(payload, "\n"), []byte("\n"))
+
+	for n := 0; n < len(lines); n++ {
+		var meta map[string]json.RawMessage
+		item := bulkItem{meta: lines[n]}
+
+		if err := json.Unmarshal(lines[n], &meta); err != nil {
+			log.Fatalf("Error parsing bulk payload: %s", err)
+		}
+
+		if _, ok := meta["delete"]; !ok && n+1 < len(lines) {
+			n++
+			item.source = lines[n]
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns exponential backoff delay with jitter for the given attempt
+func retryDelay(attempt int) time.Duration {
+	if attempt > 6 {
+		attempt = 6
+	}
+
+	jitter := time.Duration(rand.Intn(1000)) * time.Millisecond
+
+	return time.Duration(1<<uint(attempt))*time.Second + jitter
+}
+
+// processBulkResponse walks through response items, counts indexed and skipped documents,
+// reports non-retryable failures and returns items which should be sent again
+func (es *Client) processBulkResponse(payload *bytes.Buffer, r *bulkResponse) (retry *bytes.Buffer, success bool) {
+	retry = new(bytes.Buffer)
+
+	if !r.Errors {
+		es.countBulk(len(r.Items), 0)
+		return retry, true
+	}
+
+	items := splitBulkPayload(payload.Bytes())
+
+	if len(items) != len(r.Items) {
+		log.Printf("Bulk response has %d items while %d were sent", len(r.Items), len(items))
+		return nil, false
+	}
+
+	indexed, skipped := 0, 0
+
+	for n, result := range r.Items {
+		for _, item := range result {
+			switch {
+			case item.Error == nil:
+				indexed++
+			case item.Status == http.StatusConflict:
+				skipped++
+			case isRetryableStatus(item.Status):
+				items[n].writeTo(retry)
+			default:
+				es.drop(items[n], item)
+			}
+		}
+	}
+
+	es.countBulk(indexed, skipped)
+
+	return retry, true
+}
+
+func (es *Client) countBulk(indexed, skipped int) {
+	es.statsMutex.Lock()
+	defer es.statsMutex.Unlock()
+
+	es.stats.Indexed += indexed
+	es.stats.Skipped += skipped
+}
+
+func (es *Client) drop(item bulkItem, result bulkResponseItem) {
+	es.statsMutex.Lock()
+	defer es.statsMutex.Unlock()
+
+	if es.stats.Dropped == nil {
+		es.stats.Dropped = make(map[IndexName]int)
+	}
+
+	es.stats.Dropped[IndexName(result.Index)]++
+
+	log.Printf(
+		"Dropped %s document %s (ledger %d), status %d, %s: %s\n%s",
+		result.Index, result.ID, item.ledgerSeq(), result.Status, result.Error.Type, result.Error.Reason, item.source,
+	)
+}
+
+// BulkStats returns the summary of documents sent to ElasticSearch so far
+func (es *Client) BulkStats() BulkStats {
+	es.statsMutex.Lock()
+	defer es.statsMutex.Unlock()
+
+	stats := BulkStats{
+		Indexed: es.stats.Indexed,
+		Skipped: es.stats.Skipped,
+		Dropped: make(map[IndexName]int),
+	}
+
+	for name, count := range es.stats.Dropped {
+		stats.Dropped[name] = count
+	}
+
+	return stats
+}
@@ -0,0 +1,63 @@
+package es
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Cursor represents the last ledger fully indexed by a named process
+type Cursor struct {
+	Name      string    `json:"name"`
+	LedgerSeq int       `json:"ledger_seq"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LoadCursor returns the ledger seq persisted for the cursor name, zero if cursor does not exist
+func (es *Client) LoadCursor(name string) int {
+	var r struct {
+		Source Cursor `json:"_source"`
+	}
+
+	res, err := es.rawClient.Get(string(es.indices.Name(stateIndexName)), name)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return 0
+	}
+
+	fatalIfError(res, err)
+
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		log.Fatalf("Error parsing the response body: %s", err)
+	}
+
+	return r.Source.LedgerSeq
+}
+
+// SaveCursor persists the ledger seq for the cursor name
+func (es *Client) SaveCursor(name string, seq int) {
+	var buf bytes.Buffer
+
+	cursor := Cursor{Name: name, LedgerSeq: seq, UpdatedAt: time.Now().UTC()}
+
+	if err := json.NewEncoder(&buf).Encode(cursor); err != nil {
+		log.Fatalf("Error encoding cursor: %s", err)
+	}
+
+	res, err := es.rawClient.Index(
+		string(es.indices.Name(stateIndexName)),
+		&buf,
+		es.rawClient.Index.WithDocumentID(name),
+	)
+
+	fatalIfError(res, err)
+	res.Body.Close()
+}
@@ -1,7 +1,105 @@
 package es
 
-// DataEntry represents data entry
+import (
+	"encoding/base64"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stellar/go/xdr"
+)
+
+// DataEntry represents data entry, value is base64 encoded as it may contain binary data
 type DataEntry struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	ValueText string `json:"value_text,omitempty"`
+}
+
+// NewDataEntry creates DataEntry with base64 encoded value, text values are also stored as is
+func NewDataEntry(name xdr.String64, value []byte) *DataEntry {
+	entry := &DataEntry{Name: string(name)}
+
+	if len(value) > 0 {
+		entry.Value = base64.StdEncoding.EncodeToString(value)
+
+		if isText(value) {
+			entry.ValueText = string(value)
+		}
+	}
+
+	return entry
+}
+
+// isText returns true if value is valid UTF-8 having no control characters except whitespace
+func isText(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	for _, r := range string(value) {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AccountData represents the latest state of an account data entry
+type AccountData struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	DataEntry
+	LastModifiedLedger int       `json:"last_modified_ledger"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Removed            bool      `json:"removed,omitempty"`
+}
+
+// NewAccountData creates AccountData from DataEntry ledger entry
+func NewAccountData(d xdr.DataEntry, seq int, closeTime time.Time) *AccountData {
+	return &AccountData{
+		ID:                 accountDataID(d.AccountId.Address(), d.DataName),
+		AccountID:          d.AccountId.Address(),
+		DataEntry:          *NewDataEntry(d.DataName, d.DataValue),
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+	}
+}
+
+// NewRemovedAccountData creates AccountData representing removed data entry
+func NewRemovedAccountData(key xdr.LedgerKeyData, seq int, closeTime time.Time) *AccountData {
+	return &AccountData{
+		ID:                 accountDataID(key.AccountId.Address(), key.DataName),
+		AccountID:          key.AccountId.Address(),
+		DataEntry:          DataEntry{Name: string(key.DataName)},
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+		Removed:            true,
+	}
+}
+
+// accountDataID returns document id, name is encoded since it may contain any characters
+func accountDataID(accountID string, name xdr.String64) string {
+	return accountID + "-" + base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// DocID returns account id and encoded entry name pair
+func (d *AccountData) DocID() *string {
+	return &d.ID
+}
+
+// IndexName data entries index name
+func (d *AccountData) IndexName() IndexName {
+	return dataEntriesIndexName
+}
+
+// StateSeq returns ledger seq data entry state was taken at
+func (d *AccountData) StateSeq() int {
+	return d.LastModifiedLedger
+}
+
+// IsRemoved returns true if data entry was removed
+func (d *AccountData) IsRemoved() bool {
+	return d.Removed
 }
@@ -0,0 +1,72 @@
+package es
+
+import (
+	"time"
+)
+
+// EffectType represents the kind of effect
+type EffectType string
+
+const (
+	EffectAccountCreated               EffectType = "account_created"
+	EffectAccountRemoved               EffectType = "account_removed"
+	EffectAccountCredited              EffectType = "account_credited"
+	EffectAccountDebited               EffectType = "account_debited"
+	EffectAccountThresholdsUpdated     EffectType = "account_thresholds_updated"
+	EffectAccountHomeDomainUpdated     EffectType = "account_home_domain_updated"
+	EffectAccountFlagsUpdated          EffectType = "account_flags_updated"
+	EffectAccountInflationDestUpdated  EffectType = "account_inflation_destination_updated"
+	EffectSequenceBumped               EffectType = "sequence_bumped"
+	EffectSignerCreated                EffectType = "signer_created"
+	EffectSignerRemoved                EffectType = "signer_removed"
+	EffectSignerUpdated                EffectType = "signer_updated"
+	EffectTrustlineCreated             EffectType = "trustline_created"
+	EffectTrustlineRemoved             EffectType = "trustline_removed"
+	EffectTrustlineUpdated             EffectType = "trustline_updated"
+	EffectTrustlineAuthorized          EffectType = "trustline_authorized"
+	EffectTrustlineDeauthorized        EffectType = "trustline_deauthorized"
+	EffectTrustlineMaintainLiabilities EffectType = "trustline_authorized_to_maintain_liabilities"
+	EffectOfferCreated                 EffectType = "offer_created"
+	EffectOfferRemoved                 EffectType = "offer_removed"
+	EffectOfferUpdated                 EffectType = "offer_updated"
+	EffectDataCreated                  EffectType = "data_created"
+	EffectDataRemoved                  EffectType = "data_removed"
+	EffectDataUpdated                  EffectType = "data_updated"
+)
+
+// Effect represents single change made by an operation to the ledger state
+type Effect struct {
+	ID            string      `json:"id"`
+	PagingToken   PagingToken `json:"paging_token"`
+	Type          EffectType  `json:"type"`
+	AccountID     string      `json:"account_id"`
+	TxID          string      `json:"tx_id"`
+	TxIndex       int         `json:"tx_idx"`
+	Index         int         `json:"idx"`
+	Seq           int         `json:"seq"`
+	OperationType string      `json:"operation_type"`
+	CloseTime     time.Time   `json:"close_time"`
+
+	Asset         *Asset             `json:"asset,omitempty"`
+	Amount        string             `json:"amount,omitempty"`
+	Limit         string             `json:"limit,omitempty"`
+	OfferID       int64              `json:"offer_id,omitempty"`
+	Signer        *Signer            `json:"signer,omitempty"`
+	Data          *DataEntry         `json:"data,omitempty"`
+	BumpTo        int64              `json:"bump_to,omitempty"`
+	Thresholds    *AccountThresholds `json:"thresholds,omitempty"`
+	HomeDomain    string             `json:"home_domain,omitempty"`
+	InflationDest string             `json:"inflation_dest_id,omitempty"`
+	Flags         *AccountFlags      `json:"flags,omitempty"`
+}
+
+// DocID effect es document id
+func (e *Effect) DocID() *string {
+	s := e.PagingToken.String()
+	return &s
+}
+
+// IndexName effects index name
+func (e *Effect) IndexName() IndexName {
+	return effectsIndexName
+}
@@ -0,0 +1,288 @@
+package es
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+)
+
+// EffectExtractor is temporary struct holding data essential for extracting effects from operation changes
+type EffectExtractor struct {
+	changes         []xdr.LedgerEntryChange
+	operation       *Operation
+	basePagingToken PagingToken
+
+	states  map[string]xdr.LedgerEntryData
+	effects []*Effect
+	index   int
+}
+
+// ProduceEffects constructs effect extractor and returns effects of the operation
+func ProduceEffects(changes []xdr.LedgerEntryChange, operation *Operation, basePagingToken PagingToken) []*Effect {
+	e := &EffectExtractor{
+		changes:         changes,
+		operation:       operation,
+		basePagingToken: basePagingToken,
+		states:          make(map[string]xdr.LedgerEntryData),
+	}
+
+	return e.extract()
+}
+
+// Extract effects from current changes list
+func (e *EffectExtractor) extract() []*Effect {
+	for _, change := range e.changes {
+		switch t := change.Type; t {
+		case xdr.LedgerEntryChangeTypeLedgerEntryState:
+			state := change.MustState().Data
+			e.states[entryKey(state)] = state
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+			e.created(change.MustCreated().Data)
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+			updated := change.MustUpdated().Data
+			state, ok := e.states[entryKey(updated)]
+
+			if ok {
+				e.updated(state, updated)
+			}
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			e.removed(change.MustRemoved())
+		}
+	}
+
+	return e.effects
+}
+
+func (e *EffectExtractor) add(t EffectType, accountID string) *Effect {
+	e.index++
+	pagingToken := PagingToken{EffectIndex: e.index}.Merge(e.basePagingToken)
+
+	effect := &Effect{
+		ID:            pagingToken.String(),
+		PagingToken:   pagingToken,
+		Type:          t,
+		AccountID:     accountID,
+		TxID:          e.operation.TxID,
+		TxIndex:       e.operation.TxIndex,
+		Index:         e.operation.Index,
+		Seq:           e.operation.Seq,
+		OperationType: e.operation.Type,
+		CloseTime:     e.operation.CloseTime,
+	}
+
+	e.effects = append(e.effects, effect)
+
+	return effect
+}
+
+func (e *EffectExtractor) created(data xdr.LedgerEntryData) {
+	switch data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := data.MustAccount()
+		effect := e.add(EffectAccountCreated, account.AccountId.Address())
+		effect.Amount = amount.String(account.Balance)
+
+		for _, signer := range account.Signers {
+			effect := e.add(EffectSignerCreated, account.AccountId.Address())
+			effect.Signer = NewSigner(&signer)
+		}
+
+	case xdr.LedgerEntryTypeTrustline:
+		line := data.MustTrustLine()
+		effect := e.add(EffectTrustlineCreated, line.AccountId.Address())
+		effect.Asset = NewAsset(&line.Asset)
+		effect.Limit = amount.String(line.Limit)
+
+	case xdr.LedgerEntryTypeOffer:
+		offer := data.MustOffer()
+		effect := e.add(EffectOfferCreated, offer.SellerId.Address())
+		effect.OfferID = int64(offer.OfferId)
+
+	case xdr.LedgerEntryTypeData:
+		entry := data.MustData()
+		effect := e.add(EffectDataCreated, entry.AccountId.Address())
+		effect.Data = NewDataEntry(entry.DataName, entry.DataValue)
+	}
+}
+
+func (e *EffectExtractor) updated(state xdr.LedgerEntryData, data xdr.LedgerEntryData) {
+	switch data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		e.accountUpdated(state.MustAccount(), data.MustAccount())
+
+	case xdr.LedgerEntryTypeTrustline:
+		e.trustlineUpdated(state.MustTrustLine(), data.MustTrustLine())
+
+	case xdr.LedgerEntryTypeOffer:
+		offer := data.MustOffer()
+		effect := e.add(EffectOfferUpdated, offer.SellerId.Address())
+		effect.OfferID = int64(offer.OfferId)
+
+	case xdr.LedgerEntryTypeData:
+		entry := data.MustData()
+		effect := e.add(EffectDataUpdated, entry.AccountId.Address())
+		effect.Data = NewDataEntry(entry.DataName, entry.DataValue)
+	}
+}
+
+func (e *EffectExtractor) accountUpdated(state xdr.AccountEntry, account xdr.AccountEntry) {
+	address := account.AccountId.Address()
+
+	e.balanceChanged(address, NewNativeAsset(), account.Balance-state.Balance)
+
+	if account.SeqNum != state.SeqNum {
+		effect := e.add(EffectSequenceBumped, address)
+		effect.BumpTo = int64(account.SeqNum)
+	}
+
+	if account.Thresholds != state.Thresholds {
+		effect := e.add(EffectAccountThresholdsUpdated, address)
+		effect.Thresholds = NewAccountThresholdsFromXdr(account.Thresholds)
+	}
+
+	if account.HomeDomain != state.HomeDomain {
+		effect := e.add(EffectAccountHomeDomainUpdated, address)
+		effect.HomeDomain = string(account.HomeDomain)
+	}
+
+	if account.Flags != state.Flags {
+		flags := xdr.Uint32(account.Flags)
+
+		effect := e.add(EffectAccountFlagsUpdated, address)
+		effect.Flags = NewAccountFlags(&flags)
+	}
+
+	if inflationDest(account) != inflationDest(state) {
+		effect := e.add(EffectAccountInflationDestUpdated, address)
+		effect.InflationDest = inflationDest(account)
+	}
+
+	e.signersUpdated(address, state.Signers, account.Signers)
+}
+
+func (e *EffectExtractor) signersUpdated(address string, before []xdr.Signer, after []xdr.Signer) {
+	weights := make(map[string]xdr.Uint32)
+
+	for _, signer := range before {
+		weights[signer.Key.Address()] = signer.Weight
+	}
+
+	for i, signer := range after {
+		key := signer.Key.Address()
+		weight, ok := weights[key]
+		delete(weights, key)
+
+		if !ok {
+			effect := e.add(EffectSignerCreated, address)
+			effect.Signer = NewSigner(&after[i])
+		} else if weight != signer.Weight {
+			effect := e.add(EffectSignerUpdated, address)
+			effect.Signer = NewSigner(&after[i])
+		}
+	}
+
+	for i, signer := range before {
+		if _, ok := weights[signer.Key.Address()]; ok {
+			effect := e.add(EffectSignerRemoved, address)
+			effect.Signer = NewSigner(&before[i])
+			effect.Signer.Weight = 0
+		}
+	}
+}
+
+func (e *EffectExtractor) trustlineUpdated(state xdr.TrustLineEntry, line xdr.TrustLineEntry) {
+	address := line.AccountId.Address()
+	asset := NewAsset(&line.Asset)
+
+	e.balanceChanged(address, asset, line.Balance-state.Balance)
+
+	if line.Limit != state.Limit {
+		effect := e.add(EffectTrustlineUpdated, address)
+		effect.Asset = asset
+		effect.Limit = amount.String(line.Limit)
+	}
+
+	if line.Flags != state.Flags {
+		t := EffectTrustlineDeauthorized
+		flags := xdr.TrustLineFlags(line.Flags)
+
+		if flags.IsAuthorized() {
+			t = EffectTrustlineAuthorized
+		} else if flags.IsAuthorizedToMaintainLiabilitiesFlag() {
+			t = EffectTrustlineMaintainLiabilities
+		}
+
+		effect := e.add(t, address)
+		effect.Asset = asset
+	}
+}
+
+func (e *EffectExtractor) balanceChanged(address string, asset *Asset, diff xdr.Int64) {
+	if diff == 0 {
+		return
+	}
+
+	t := EffectAccountCredited
+	if diff < 0 {
+		t = EffectAccountDebited
+		diff = -diff
+	}
+
+	effect := e.add(t, address)
+	effect.Asset = asset
+	effect.Amount = amount.String(diff)
+}
+
+func (e *EffectExtractor) removed(key xdr.LedgerKey) {
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := key.MustAccount()
+		e.add(EffectAccountRemoved, account.AccountId.Address())
+
+	case xdr.LedgerEntryTypeTrustline:
+		line := key.MustTrustLine()
+		effect := e.add(EffectTrustlineRemoved, line.AccountId.Address())
+		effect.Asset = NewAsset(&line.Asset)
+
+	case xdr.LedgerEntryTypeOffer:
+		offer := key.MustOffer()
+		effect := e.add(EffectOfferRemoved, offer.SellerId.Address())
+		effect.OfferID = int64(offer.OfferId)
+
+	case xdr.LedgerEntryTypeData:
+		entry := key.MustData()
+		effect := e.add(EffectDataRemoved, entry.AccountId.Address())
+		effect.Data = &DataEntry{Name: string(entry.DataName)}
+	}
+}
+
+func inflationDest(a xdr.AccountEntry) string {
+	if a.InflationDest == nil {
+		return ""
+	}
+
+	return a.InflationDest.Address()
+}
+
+// entryKey returns the string identifying ledger entry, used to match entry states with updates
+func entryKey(data xdr.LedgerEntryData) string {
+	switch data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := data.MustAccount()
+		return "account:" + account.AccountId.Address()
+	case xdr.LedgerEntryTypeTrustline:
+		line := data.MustTrustLine()
+		return "trustline:" + line.AccountId.Address() + ":" + NewAsset(&line.Asset).ID
+	case xdr.LedgerEntryTypeOffer:
+		return fmt.Sprintf("offer:%d", data.MustOffer().OfferId)
+	case xdr.LedgerEntryTypeData:
+		entry := data.MustData()
+		return "data:" + entry.AccountId.Address() + ":" + string(entry.DataName)
+	}
+
+	return ""
+}
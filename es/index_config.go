@@ -0,0 +1,98 @@
+package es
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+)
+
+// IndexConfig represents deployment specific index name prefix and settings overrides
+type IndexConfig struct {
+	Prefix  string                      `json:"prefix"`
+	Indices map[IndexName]IndexSettings `json:"indices"`
+}
+
+// IndexSettings represents index settings which may be overridden per deployment
+type IndexSettings struct {
+	NumberOfShards   *int   `json:"number_of_shards,omitempty"`
+	NumberOfReplicas *int   `json:"number_of_replicas,omitempty"`
+	RefreshInterval  string `json:"refresh_interval,omitempty"`
+}
+
+// LoadIndexConfig reads index config from JSON file, empty path means defaults
+func LoadIndexConfig(path string) IndexConfig {
+	var config IndexConfig
+
+	if path == "" {
+		return config
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		log.Fatalf("Error parsing index config %s: %s", path, err)
+	}
+
+	for name := range config.Indices {
+		if _, ok := indexVersions[name]; !ok {
+			log.Fatalf("Unknown index %s in index config %s", name, path)
+		}
+	}
+
+	return config
+}
+
+// Name returns the name of the alias the index is accessed through, e.g. pubnet-op
+func (c IndexConfig) Name(name IndexName) IndexName {
+	return IndexName(c.Prefix) + name
+}
+
+// Definition returns the index definition with settings overrides applied
+func (c IndexConfig) Definition(name IndexName, def IndexDefinition) IndexDefinition {
+	overrides, ok := c.Indices[name]
+	if !ok {
+		return def
+	}
+
+	var body map[string]interface{}
+
+	if err := json.Unmarshal([]byte(def), &body); err != nil {
+		log.Fatalf("Error parsing %s index definition: %s", name, err)
+	}
+
+	settings := childObject(childObject(body, "settings"), "index")
+
+	if overrides.NumberOfShards != nil {
+		settings["number_of_shards"] = *overrides.NumberOfShards
+	}
+
+	if overrides.NumberOfReplicas != nil {
+		settings["number_of_replicas"] = *overrides.NumberOfReplicas
+	}
+
+	if overrides.RefreshInterval != "" {
+		settings["refresh_interval"] = overrides.RefreshInterval
+	}
+
+	result, err := json.Marshal(body)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return IndexDefinition(result)
+}
+
+// childObject returns nested JSON object creating it if missing
+func childObject(parent map[string]interface{}, key string) map[string]interface{} {
+	child, ok := parent[key].(map[string]interface{})
+
+	if !ok {
+		child = make(map[string]interface{})
+		parent[key] = child
+	}
+
+	return child
+}
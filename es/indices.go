@@ -7,12 +7,19 @@ type IndexName string
 type IndexDefinition string
 
 const (
-	ledgerHeaderIndexName  IndexName = "ledger"
-	txIndexName            IndexName = "tx"
-	opIndexName            IndexName = "op"
-	balanceIndexName       IndexName = "balance"
-	tradesIndexName        IndexName = "trades"
-	signerHistoryIndexName IndexName = "signers"
+	ledgerHeaderIndexName     IndexName = "ledger"
+	txIndexName               IndexName = "tx"
+	opIndexName               IndexName = "op"
+	balanceIndexName          IndexName = "balance"
+	tradesIndexName           IndexName = "trades"
+	signerHistoryIndexName    IndexName = "signers"
+	stateIndexName            IndexName = "state"
+	effectsIndexName          IndexName = "effects"
+	accountsIndexName         IndexName = "accounts"
+	offersIndexName           IndexName = "offers"
+	trustlinesIndexName       IndexName = "trustlines"
+	dataEntriesIndexName      IndexName = "data_entries"
+	inflationPayoutsIndexName IndexName = "inflation_payouts"
 )
 
 // GetIndexDefinitions returns ElasticSearch index definitions for Astrologer indices
@@ -40,6 +47,7 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
               "version": { "type": "long" },
               "total_coins": { "type": "long" },
               "fee_pool": { "type": "long" },
+              "inflation_seq": { "type": "long" },
               "id_pool": { "type": "long" },
               "base_fee": { "type": "long" },
               "base_reserve": { "type": "long" },
@@ -72,6 +80,10 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 				"successful": { "type": "boolean" },
 				"result_code": { "type": "integer" },
 				"source_account_id": { "type": "keyword", "index": true },
+				"source_account_muxed": { "type": "keyword", "index": true },
+				"source_account_muxed_id": { "type": "keyword", "index": true },
+				"fee_account_muxed": { "type": "keyword", "index": true },
+				"fee_account_muxed_id": { "type": "keyword", "index": true },
 				"time_bounds": {
 					"properties": {
 						"min_time": { "type": "long" },
@@ -83,7 +95,32 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 						"type": { "type": "byte" },
 						"value": { "type": "keyword" }
 					}
-				}
+				},
+				"signatures": {
+					"properties": {
+						"hint": { "type": "keyword" },
+						"signature": { "type": "keyword", "index": false }
+					}
+				},
+				"inner_transaction": {
+					"properties": {
+						"id": { "type": "keyword", "index": true },
+						"max_fee": { "type": "long" },
+						"fee_charged": { "type": "long" },
+						"successful": { "type": "boolean" },
+						"result_code": { "type": "integer" },
+						"signatures": {
+							"properties": {
+								"hint": { "type": "keyword" },
+								"signature": { "type": "keyword", "index": false }
+							}
+						}
+					}
+				},
+				"envelope_xdr": { "type": "binary" },
+				"result_xdr": { "type": "binary" },
+				"result_meta_xdr": { "type": "binary" },
+				"fee_meta_xdr": { "type": "binary" }
 			}
 		}
 	}
@@ -118,6 +155,8 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 				},
 				"type": { "type": "keyword", "index": true },
 				"source_account_id": { "type": "keyword", "index": true },
+				"source_account_muxed": { "type": "keyword", "index": true },
+				"source_account_muxed_id": { "type": "keyword", "index": true },
 				"source_asset": {
 					"properties": {
 						"id": { "type": "keyword" },
@@ -126,7 +165,11 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 					}
 				},
 				"source_amount": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"amount_received": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"amount_sent": { "type": "scaled_float", "scaling_factor": 10000000 },
 				"destination_account_id": { "type": "keyword", "index": true },
+				"destination_account_muxed": { "type": "keyword", "index": true },
+				"destination_account_muxed_id": { "type": "keyword", "index": true },
 				"destination_asset": {
 					"properties": {
 						"id": { "type": "keyword" },
@@ -187,7 +230,8 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 				"data": {
 					"properties": {
 						"name": { "type": "keyword" },
-						"value": { "type": "keyword" }
+						"value": { "type": "keyword" },
+						"value_text": { "type": "keyword" }
 					}
 				},
 				"result_source_account_balance": { "type": "scaled_float", "scaling_factor": 10000000 },
@@ -219,7 +263,25 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 						"seller_id": { "type": "keyword" }
 					}
 				},
-				"result_offer_effect": { "type": "keyword" }
+				"result_offer_effect": { "type": "keyword" },
+				"result_last_amount": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"result_last_asset": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"result_last_destination": { "type": "keyword" },
+				"result_no_issuer": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"inflation_payouts_count": { "type": "integer" },
+				"inflation_payouts_total": { "type": "scaled_float", "scaling_factor": 10000000 }
 			}
 		}
 	}
@@ -239,8 +301,14 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 				"paging_token": { "type": "keyword", "index": true },
 				"account_id": { "type": "keyword", "index": true },
 				"value": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"prev_value": { "type": "scaled_float", "scaling_factor": 10000000 },
 				"diff": { "type": "scaled_float", "scaling_factor": 10000000 },
 				"positive": { "type": "boolean", "index": true },
+				"removed": { "type": "boolean" },
+				"buying_liabilities": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"selling_liabilities": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"minimum_reserve": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"available": { "type": "scaled_float", "scaling_factor": 10000000 },
 				"source": { "type": "keyword" },
 				"created_at": { "type": "date" },
 				"asset": {
@@ -284,11 +352,11 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 						"issuer": { "type": "keyword" }
 					}
 				},
-				"offer_id": { "type": "long" },
+				"sold_offer_id": { "type": "long" },
 				"seller_id": { "type": "keyword", "index": true },
 				"buyer_id": { "type": "keyword", "index": true },
 				"price": { "type": "scaled_float", "scaling_factor": 10000000 },
-				"time": { "type": "date" }
+				"ledger_close_time": { "type": "date" }
 			}
 		}
 	}
@@ -311,6 +379,8 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 				"signer": { "type": "keyword", "index": true },
 				"type": { "type": "byte" },
 				"weight": { "type": "integer" },
+				"prev_weight": { "type": "integer" },
+				"action": { "type": "keyword" },
 				"seq": { "type": "integer" },
 				"tx_idx": { "type": "integer" },
 				"idx": { "type": "integer" },
@@ -320,5 +390,260 @@ func GetIndexDefinitions() map[IndexName]IndexDefinition {
 	}
 `
 
+	m[effectsIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"sort.field" : "paging_token",
+				"sort.order" : "desc",
+				"number_of_shards" : 4
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"paging_token": { "type": "keyword", "index": true },
+				"type": { "type": "keyword", "index": true },
+				"account_id": { "type": "keyword", "index": true },
+				"tx_id": { "type": "keyword", "index": true },
+				"tx_idx": { "type": "integer" },
+				"idx": { "type": "integer" },
+				"seq": { "type": "integer" },
+				"operation_type": { "type": "keyword" },
+				"close_time": { "type": "date" },
+				"asset": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"amount": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"limit": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"offer_id": { "type": "long" },
+				"signer": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"weight": { "type": "integer" },
+						"type": { "type": "byte" }
+					}
+				},
+				"data": {
+					"properties": {
+						"name": { "type": "keyword" },
+						"value": { "type": "keyword" },
+						"value_text": { "type": "keyword" }
+					}
+				},
+				"bump_to": { "type": "long" },
+				"thresholds": {
+					"properties": {
+						"low": { "type": "integer" },
+						"medium": { "type": "integer" },
+						"high": { "type": "integer" },
+						"master": { "type": "integer" }
+					}
+				},
+				"home_domain": { "type": "keyword" },
+				"inflation_dest_id": { "type": "keyword" },
+				"flags": {
+					"properties": {
+						"required": { "type": "boolean" },
+						"revocable": { "type": "boolean" },
+						"immutable": { "type": "boolean" }
+					}
+				}
+			}
+		}
+	}
+`
+
+	m[accountsIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"number_of_shards" : 4,
+				"gc_deletes" : "3650d"
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"balance": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"seq_num": { "type": "long" },
+				"num_subentries": { "type": "integer" },
+				"inflation_dest_id": { "type": "keyword" },
+				"home_domain": { "type": "keyword" },
+				"flags": {
+					"properties": {
+						"required": { "type": "boolean" },
+						"revocable": { "type": "boolean" },
+						"immutable": { "type": "boolean" }
+					}
+				},
+				"thresholds": {
+					"properties": {
+						"low": { "type": "integer" },
+						"medium": { "type": "integer" },
+						"high": { "type": "integer" },
+						"master": { "type": "integer" }
+					}
+				},
+				"signers": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"weight": { "type": "integer" },
+						"type": { "type": "byte" }
+					}
+				},
+				"last_modified_ledger": { "type": "integer" },
+				"updated_at": { "type": "date" },
+				"removed": { "type": "boolean" }
+			}
+		}
+	}
+`
+
+	m[offersIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"number_of_shards" : 2,
+				"gc_deletes" : "3650d"
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"offer_id": { "type": "long" },
+				"seller_id": { "type": "keyword", "index": true },
+				"amount": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"price": { "type": "double" },
+				"price_n_d": {
+					"properties": {
+						"n": { "type": "integer" },
+						"d": { "type": "integer" }
+					}
+				},
+				"selling": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"buying": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"passive": { "type": "boolean" },
+				"last_modified_ledger": { "type": "integer" },
+				"updated_at": { "type": "date" },
+				"removed": { "type": "boolean" }
+			}
+		}
+	}
+`
+
+	m[trustlinesIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"number_of_shards" : 4,
+				"gc_deletes" : "3650d"
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"account_id": { "type": "keyword", "index": true },
+				"asset": {
+					"properties": {
+						"id": { "type": "keyword" },
+						"code": { "type": "keyword" },
+						"issuer": { "type": "keyword" }
+					}
+				},
+				"balance": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"limit": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"buying_liabilities": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"selling_liabilities": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"authorization": { "type": "keyword" },
+				"last_modified_ledger": { "type": "integer" },
+				"updated_at": { "type": "date" },
+				"removed": { "type": "boolean" }
+			}
+		}
+	}
+`
+
+	m[dataEntriesIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"number_of_shards" : 1,
+				"gc_deletes" : "3650d"
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"account_id": { "type": "keyword", "index": true },
+				"name": { "type": "keyword", "index": true },
+				"value": { "type": "keyword" },
+				"value_text": { "type": "keyword" },
+				"last_modified_ledger": { "type": "integer" },
+				"updated_at": { "type": "date" },
+				"removed": { "type": "boolean" }
+			}
+		}
+	}
+`
+
+	m[inflationPayoutsIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"sort.field" : "paging_token",
+				"sort.order" : "desc",
+				"number_of_shards" : 1
+			}
+		},
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword", "index": true },
+				"paging_token": { "type": "keyword", "index": true },
+				"destination_id": { "type": "keyword", "index": true },
+				"amount": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"tx_id": { "type": "keyword", "index": true },
+				"tx_idx": { "type": "integer" },
+				"idx": { "type": "integer" },
+				"seq": { "type": "integer" },
+				"close_time": { "type": "date" }
+			}
+		}
+	}
+`
+
+	m[stateIndexName] = `
+	{
+		"settings": {
+			"index" : {
+				"number_of_shards" : 1
+			}
+		},
+		"mappings": {
+			"properties": {
+				"name": { "type": "keyword", "index": true },
+				"ledger_seq": { "type": "long" },
+				"updated_at": { "type": "date" }
+			}
+		}
+	}
+`
+
 	return m
 }
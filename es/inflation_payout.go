@@ -0,0 +1,73 @@
+package es
+
+import (
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+)
+
+// InflationPayout represents single payout of the inflation operation
+type InflationPayout struct {
+	ID            string      `json:"id"`
+	PagingToken   PagingToken `json:"paging_token"`
+	DestinationID string      `json:"destination_id"`
+	Amount        string      `json:"amount"`
+	TxID          string      `json:"tx_id"`
+	TxIndex       int         `json:"tx_idx"`
+	Index         int         `json:"idx"`
+	Seq           int         `json:"seq"`
+	CloseTime     time.Time   `json:"close_time"`
+}
+
+// ProduceInflationPayouts returns payouts of successful inflation operation
+func ProduceInflationPayouts(r *xdr.OperationResult, op *Operation) (payouts []*InflationPayout) {
+	for i, payout := range inflationPayouts(r) {
+		token := PagingToken{
+			LedgerSeq:        op.Seq,
+			TransactionOrder: op.TxIndex,
+			OperationOrder:   op.Index,
+			EffectIndex:      i + 1,
+		}
+
+		payouts = append(payouts, &InflationPayout{
+			ID:            token.String(),
+			PagingToken:   token,
+			DestinationID: payout.Destination.Address(),
+			Amount:        amount.String(payout.Amount),
+			TxID:          op.TxID,
+			TxIndex:       op.TxIndex,
+			Index:         op.Index,
+			Seq:           op.Seq,
+			CloseTime:     op.CloseTime,
+		})
+	}
+
+	return payouts
+}
+
+// inflationPayouts returns payouts from operation result, nil if it is not a successful inflation
+func inflationPayouts(r *xdr.OperationResult) []xdr.InflationPayout {
+	if r == nil || r.Code != xdr.OperationResultCodeOpInner {
+		return nil
+	}
+
+	result, ok := r.Tr.GetInflationResult()
+	if !ok {
+		return nil
+	}
+
+	payouts, _ := result.GetPayouts()
+	return payouts
+}
+
+// DocID inflation payout es document id
+func (p *InflationPayout) DocID() *string {
+	s := p.PagingToken.String()
+	return &s
+}
+
+// IndexName inflation payouts index name
+func (p *InflationPayout) IndexName() IndexName {
+	return inflationPayoutsIndexName
+}
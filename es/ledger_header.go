@@ -1,9 +1,10 @@
 package es
 
 import (
+	"encoding/hex"
 	"time"
 
-	"github.com/astroband/astrologer/db"
+	"github.com/astroband/astrologer/source"
 )
 
 // LedgerHeader represents json-serializable struct for LedgerHeader to index
@@ -22,29 +23,30 @@ type LedgerHeader struct {
 	IDPool         int         `json:"id_pool"`
 	BaseFee        int         `json:"base_fee"`
 	BaseReserve    int         `json:"base_reserve"`
-	MaxTxSetSize   int         `json:"max_tx_size"`
+	MaxTxSetSize   int         `json:"max_tx_set_size"`
 }
 
-// NewLedgerHeader creates LedgerHeader from LedgerHeaderRow
-func NewLedgerHeader(row *db.LedgerHeaderRow) *LedgerHeader {
-	pagingToken := PagingToken{LedgerSeq: row.LedgerSeq}
+// NewLedgerHeader creates LedgerHeader from LedgerCloseData
+func NewLedgerHeader(data *source.LedgerCloseData) *LedgerHeader {
+	header := data.Header.Header
+	pagingToken := PagingToken{LedgerSeq: data.Seq()}
 
 	return &LedgerHeader{
 		ID:             pagingToken.String(),
-		Hash:           row.Hash,
-		PrevHash:       row.PrevHash,
-		BucketListHash: row.BucketListHash,
-		Seq:            row.LedgerSeq,
+		Hash:           data.Hash(),
+		PrevHash:       hex.EncodeToString(header.PreviousLedgerHash[:]),
+		BucketListHash: hex.EncodeToString(header.BucketListHash[:]),
+		Seq:            data.Seq(),
 		PagingToken:    pagingToken,
-		CloseTime:      time.Unix(row.CloseTime, 0),
-		Version:        int(row.Data.LedgerVersion),
-		TotalCoins:     int(row.Data.TotalCoins),
-		FeePool:        int(row.Data.FeePool),
-		InflationSeq:   int(row.Data.InflationSeq),
-		IDPool:         int(row.Data.IdPool),
-		BaseFee:        int(row.Data.BaseFee),
-		BaseReserve:    int(row.Data.BaseReserve),
-		MaxTxSetSize:   int(row.Data.MaxTxSetSize),
+		CloseTime:      data.CloseTime(),
+		Version:        int(header.LedgerVersion),
+		TotalCoins:     int(header.TotalCoins),
+		FeePool:        int(header.FeePool),
+		InflationSeq:   int(header.InflationSeq),
+		IDPool:         int(header.IdPool),
+		BaseFee:        int(header.BaseFee),
+		BaseReserve:    int(header.BaseReserve),
+		MaxTxSetSize:   int(header.MaxTxSetSize),
 	}
 }
 
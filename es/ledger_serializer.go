@@ -1,61 +1,84 @@
 package es
 
 import (
-	"bytes"
 	"fmt"
 
-	"github.com/astroband/astrologer/db"
+	"github.com/astroband/astrologer/source"
 	"github.com/stellar/go/xdr"
 )
 
+// ProduceOptions represents optional parts of produced documents
+type ProduceOptions struct {
+	// RawXdr stores base64 encoded envelope, result and metas on transaction documents
+	RawXdr bool
+
+	// NetworkPassphrase is used to hash inner transactions of fee bump envelopes
+	NetworkPassphrase string
+}
+
 type ledgerSerializer struct {
-	ledgerRow       db.LedgerHeaderRow
-	transactionRows []db.TxHistoryRow
-	feeRows         []db.TxFeeHistoryRow
-	ledger          *LedgerHeader
+	data    source.LedgerCloseData
+	options ProduceOptions
+	ledger  *LedgerHeader
+	state   *stateExtractor
 
-	buffer *bytes.Buffer
+	documents []Indexable
 }
 
-// SerializeLedger serializes ledger data into ES bulk index data
-func SerializeLedger(ledgerRow db.LedgerHeaderRow, transactionRows []db.TxHistoryRow, feeRows []db.TxFeeHistoryRow, buffer *bytes.Buffer) error {
-	ledger := NewLedgerHeader(&ledgerRow)
+// ProduceDocuments converts ledger data into typed documents, ledger header always goes first
+func ProduceDocuments(data source.LedgerCloseData, options ProduceOptions) ([]Indexable, error) {
+	ledger := NewLedgerHeader(&data)
 
 	serializer := &ledgerSerializer{
-		ledgerRow:       ledgerRow,
-		transactionRows: transactionRows,
-		feeRows:         feeRows,
-		ledger:          ledger,
-		buffer:          buffer,
+		data:    data,
+		options: options,
+		ledger:  ledger,
+		state:   newStateExtractor(ledger.Seq, ledger.CloseTime),
 	}
 
-	return serializer.serialize()
+	err := serializer.serialize()
+
+	return serializer.documents, err
+}
+
+func (s *ledgerSerializer) write(obj Indexable) {
+	s.documents = append(s.documents, obj)
 }
 
 func (s *ledgerSerializer) serialize() error {
-	SerializeForBulk(s.ledger, s.buffer)
+	s.write(s.ledger)
 
-	for _, transactionRow := range s.transactionRows {
+	for _, transactionRow := range s.data.Transactions {
 		transaction, err := s.NewTransaction(&transactionRow, s.ledger.CloseTime)
 
 		if err != nil {
 			return err
 		}
 
-		SerializeForBulk(transaction, s.buffer)
+		s.write(transaction)
 
 		if transaction.Successful {
-			changes := s.feeRows[transaction.Index-1].Changes
-			s.serializeBalances(changes, transaction, nil, BalanceSourceFee)
+			s.serializeBalances(transactionRow.FeeChanges, transaction, nil, BalanceSourceFee)
 		}
 
+		s.state.apply(transactionRow.FeeChanges)
+		s.state.apply(transactionRow.TxChangesBefore())
+		signersCount := s.serializeSignerHistory(transactionRow.TxChangesBefore(), transaction, nil, 0)
+
 		s.serializeOperations(transactionRow, transaction)
+
+		s.state.apply(transactionRow.TxChangesAfter())
+		s.serializeSignerHistory(transactionRow.TxChangesAfter(), transaction, nil, signersCount)
+	}
+
+	for _, doc := range s.state.result() {
+		s.write(doc)
 	}
 
 	return nil
 }
 
-func (s *ledgerSerializer) serializeOperations(transactionRow db.TxHistoryRow, transaction *Transaction) error {
+func (s *ledgerSerializer) serializeOperations(transactionRow source.Transaction, transaction *Transaction) error {
 	effectsCount := 0
 	err, xdrs := transactionRow.Operations()
 
@@ -71,19 +94,21 @@ func (s *ledgerSerializer) serializeOperations(transactionRow db.TxHistoryRow, t
 			return fmt.Errorf("Failed to serialize operation with index %d in tx %s: %w", index, transaction.ID, err)
 		}
 
-		SerializeForBulk(operation, s.buffer)
+		s.write(operation)
 
 		if transaction.Successful {
 			metas := transactionRow.MetasFor(index)
 			if metas != nil {
 				effectsCount = s.serializeBalances(metas.Changes, transaction, operation, BalanceSourceMeta)
+				s.serializeEffects(metas.Changes, transaction, operation)
+				s.state.apply(metas.Changes)
+				s.serializeSignerHistory(metas.Changes, transaction, operation, 0)
 			}
 
 			s.serializeTrades(result, transaction, operation, effectsCount)
 
-			h := ProduceSignerHistory(operation)
-			if h != nil {
-				SerializeForBulk(h, s.buffer)
+			for _, payout := range ProduceInflationPayouts(result, operation) {
+				s.write(payout)
 			}
 		}
 	}
@@ -105,17 +130,48 @@ func (s *ledgerSerializer) serializeBalances(changes xdr.LedgerEntryChanges, tra
 		pagingToken.OperationOrder = operation.Index
 	}
 
-	balances := ProduceBalances(changes, s.ledger.CloseTime, source, pagingToken)
+	balances := ProduceBalances(changes, s.ledger.CloseTime, source, pagingToken, s.ledger.BaseReserve)
 
 	if len(balances) > 0 {
 		for _, balance := range balances {
-			SerializeForBulk(balance, s.buffer)
+			s.write(balance)
 		}
 	}
 
 	return len(balances)
 }
 
+func (s *ledgerSerializer) serializeEffects(changes xdr.LedgerEntryChanges, transaction *Transaction, operation *Operation) {
+	pagingToken := PagingToken{
+		LedgerSeq:        s.ledger.Seq,
+		TransactionOrder: transaction.Index,
+		OperationOrder:   operation.Index,
+	}
+
+	for _, effect := range ProduceEffects(changes, operation, pagingToken) {
+		s.write(effect)
+	}
+}
+
+// serializeSignerHistory writes signer changes, operation is nil for changes made by transaction itself
+func (s *ledgerSerializer) serializeSignerHistory(changes xdr.LedgerEntryChanges, transaction *Transaction, operation *Operation, startIndex int) int {
+	pagingToken := PagingToken{
+		LedgerSeq:        s.ledger.Seq,
+		TransactionOrder: transaction.Index,
+	}
+
+	if operation != nil {
+		pagingToken.OperationOrder = operation.Index
+	}
+
+	entries := ProduceSignerHistory(changes, s.ledger.CloseTime, pagingToken, startIndex)
+	for _, entry := range entries {
+		s.write(entry)
+	}
+
+	return startIndex + len(entries)
+}
+
 func (s *ledgerSerializer) serializeTrades(result *xdr.OperationResult, transaction *Transaction, operation *Operation, startIndex int) int {
 	pagingToken := PagingToken{
 		LedgerSeq:        s.ledger.Seq,
@@ -125,8 +181,8 @@ func (s *ledgerSerializer) serializeTrades(result *xdr.OperationResult, transact
 
 	trades := ProduceTrades(result, operation, s.ledger.CloseTime, pagingToken, startIndex)
 	if len(trades) > 0 {
-		for _, trade := range trades {
-			SerializeForBulk(&trade, s.buffer)
+		for i := range trades {
+			s.write(&trades[i])
 		}
 	}
 
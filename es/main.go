@@ -3,6 +3,7 @@ package es
 import (
 	"bytes"
 	"log"
+	"sync"
 
 	goES "github.com/elastic/go-elasticsearch/v7"
 )
@@ -15,6 +16,7 @@ type Indexable interface {
 
 // Adapter represents the ledger storage backend
 type Adapter interface {
+	Indices() IndexConfig
 	MinMaxSeq() (min, max int)
 	LedgerSeqRangeQuery(ranges []map[string]interface{}) map[string]interface{}
 	GetLedgerSeqsInRange(min, max int) []int
@@ -22,17 +24,31 @@ type Adapter interface {
 	IndexExists(name IndexName) bool
 	CreateIndex(name IndexName, body IndexDefinition)
 	DeleteIndex(name IndexName)
-	BulkInsert(payload *bytes.Buffer) (success bool)
+	PutTemplate(name IndexName, body IndexDefinition)
+	GetTemplate(name IndexName) (body IndexDefinition, exists bool)
+	GetMappings(name IndexName) map[IndexName]IndexDefinition
+	AliasTarget(alias IndexName) IndexName
+	PutAlias(alias IndexName, index IndexName)
+	SwapAlias(alias IndexName, from IndexName, to IndexName)
+	Reindex(from IndexName, to IndexName, script string)
+	BulkInsert(payload *bytes.Buffer) (retry *bytes.Buffer, success bool)
 	IndexWithRetries(payload *bytes.Buffer, retriesCount int)
+	BulkStats() BulkStats
+	LoadCursor(name string) int
+	SaveCursor(name string, seq int)
 }
 
 // Client is a wrapper type around ElasticSearch raw client
 type Client struct {
 	rawClient *goES.Client
+	indices   IndexConfig
+
+	stats      BulkStats
+	statsMutex sync.Mutex
 }
 
 // Connect creates a Client configured to work with the ElasticSearch cluster
-func Connect(url string) *Client {
+func Connect(url string, indices IndexConfig) *Client {
 	esCfg := goES.Config{
 		Addresses: []string{url},
 	}
@@ -42,5 +58,10 @@ func Connect(url string) *Client {
 		log.Fatal(err)
 	}
 
-	return &Client{rawClient: client}
+	return &Client{rawClient: client, indices: indices}
+}
+
+// Indices returns index names and settings of the deployment
+func (es *Client) Indices() IndexConfig {
+	return es.indices
 }
@@ -0,0 +1,211 @@
+package es
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// indexDocuments lists document types stored in every index
+var indexDocuments = map[IndexName][]interface{}{
+	ledgerHeaderIndexName:     {LedgerHeader{}},
+	txIndexName:               {Transaction{}},
+	opIndexName:               {Operation{}},
+	balanceIndexName:          {Balance{}},
+	tradesIndexName:           {Trade{}},
+	signerHistoryIndexName:    {SignerHistory{}},
+	stateIndexName:            {Cursor{}},
+	effectsIndexName:          {Effect{}},
+	accountsIndexName:         {Account{}},
+	offersIndexName:           {LiveOffer{}},
+	trustlinesIndexName:       {Trustline{}},
+	dataEntriesIndexName:      {AccountData{}},
+	inflationPayoutsIndexName: {InflationPayout{}},
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// MappingReport represents differences between JSON fields of documents and the index mapping
+type MappingReport struct {
+	Index IndexName
+
+	// Unmapped are document fields missing in the mapping
+	Unmapped []string
+
+	// Orphaned are mapped fields no document has
+	Orphaned []string
+}
+
+// OK returns true if documents and mapping match
+func (r MappingReport) OK() bool {
+	return len(r.Unmapped) == 0 && len(r.Orphaned) == 0
+}
+
+// CheckMappings compares documents of every index with its definition
+func CheckMappings(definitions map[IndexName]IndexDefinition) []MappingReport {
+	var reports []MappingReport
+
+	for name, def := range definitions {
+		reports = append(reports, CheckMapping(name, name, def))
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Index < reports[j].Index })
+
+	return reports
+}
+
+// CheckMapping compares fields of documents stored in the index with the definition, index is the
+// name reported, it may differ from the name for prefixed or physical indices
+func CheckMapping(name IndexName, index IndexName, def IndexDefinition) MappingReport {
+	documents, ok := indexDocuments[name]
+	if !ok {
+		log.Fatalf("No documents known for %s index", name)
+	}
+
+	docFields := make(map[string]bool)
+	for _, doc := range documents {
+		documentFields(reflect.TypeOf(doc), "", docFields)
+	}
+
+	mappedFields := definitionFields(name, def)
+	report := MappingReport{Index: index}
+
+	for field := range docFields {
+		if !covered(field, mappedFields) {
+			report.Unmapped = append(report.Unmapped, field)
+		}
+	}
+
+	for field := range mappedFields {
+		if !covered(field, docFields) && !hasDescendant(field, docFields) {
+			report.Orphaned = append(report.Orphaned, field)
+		}
+	}
+
+	sort.Strings(report.Unmapped)
+	sort.Strings(report.Orphaned)
+
+	return report
+}
+
+// documentFields collects dotted paths of JSON leaf fields the way encoding/json serializes them
+func documentFields(t reflect.Type, prefix string, fields map[string]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		if t.Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || t == timeType || t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		fields[prefix] = true
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		ft := f.Type
+
+		if f.Anonymous && name == "" {
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				documentFields(ft, prefix, fields)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		documentFields(ft, joinField(prefix, name), fields)
+	}
+}
+
+// definitionFields collects dotted paths of mapped leaf fields
+func definitionFields(name IndexName, def IndexDefinition) map[string]bool {
+	var body struct {
+		Mappings struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"mappings"`
+	}
+
+	if err := json.Unmarshal([]byte(def), &body); err != nil {
+		log.Fatalf("Error parsing %s index definition: %s", name, err)
+	}
+
+	fields := make(map[string]bool)
+	mappingFields(body.Mappings.Properties, "", fields)
+
+	return fields
+}
+
+func mappingFields(properties map[string]interface{}, prefix string, fields map[string]bool) {
+	for name, value := range properties {
+		path := joinField(prefix, name)
+		field, _ := value.(map[string]interface{})
+
+		if nested, ok := field["properties"].(map[string]interface{}); ok {
+			mappingFields(nested, path, fields)
+			continue
+		}
+
+		fields[path] = true
+	}
+}
+
+// covered returns true if the field or one of its parents is in the set
+func covered(field string, fields map[string]bool) bool {
+	for {
+		if fields[field] {
+			return true
+		}
+
+		i := strings.LastIndex(field, ".")
+		if i < 0 {
+			return false
+		}
+
+		field = field[:i]
+	}
+}
+
+// hasDescendant returns true if the set has fields nested into the field
+func hasDescendant(field string, fields map[string]bool) bool {
+	for f := range fields {
+		if strings.HasPrefix(f, field+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinField(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
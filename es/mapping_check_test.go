@@ -0,0 +1,61 @@
+package es
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMappings(t *testing.T) {
+	for _, report := range CheckMappings(GetIndexDefinitions()) {
+		if len(report.Unmapped) > 0 {
+			t.Errorf("%s: unmapped fields: %s", report.Index, strings.Join(report.Unmapped, ", "))
+		}
+
+		if len(report.Orphaned) > 0 {
+			t.Errorf("%s: orphaned fields: %s", report.Index, strings.Join(report.Orphaned, ", "))
+		}
+	}
+}
+
+func TestEveryIndexHasDocumentsAndVersion(t *testing.T) {
+	for name := range GetIndexDefinitions() {
+		if _, ok := indexDocuments[name]; !ok {
+			t.Errorf("%s: document types are not listed", name)
+		}
+
+		if _, ok := indexVersions[name]; !ok {
+			t.Errorf("%s: schema version is not set", name)
+		}
+	}
+}
+
+func TestCheckMappingReportsDrift(t *testing.T) {
+	def := IndexDefinition(`{
+		"mappings": {
+			"properties": {
+				"id": { "type": "keyword" },
+				"paging_token": { "type": "keyword" },
+				"sold": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"bought": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"asset_sold": { "properties": { "id": { "type": "keyword" }, "code": { "type": "keyword" } } },
+				"asset_bought": { "type": "object", "enabled": false },
+				"offer_id": { "type": "long" },
+				"seller_id": { "type": "keyword" },
+				"buyer_id": { "type": "keyword" },
+				"price": { "type": "scaled_float", "scaling_factor": 10000000 },
+				"ledger_close_time": { "type": "date" }
+			}
+		}
+	}`)
+
+	report := CheckMapping(tradesIndexName, tradesIndexName, def)
+
+	if want := []string{"asset_sold.issuer", "sold_offer_id"}; !reflect.DeepEqual(report.Unmapped, want) {
+		t.Errorf("unmapped fields %v, want %v", report.Unmapped, want)
+	}
+
+	if want := []string{"offer_id"}; !reflect.DeepEqual(report.Orphaned, want) {
+		t.Errorf("orphaned fields %v, want %v", report.Orphaned, want)
+	}
+}
@@ -1,5 +1,14 @@
 package es
 
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+)
+
 // Offer represents offer in ManageOffer
 type Offer struct {
 	Amount   string  `json:"amount"`
@@ -10,3 +19,70 @@ type Offer struct {
 	OfferID  int64   `json:"offer_id"`
 	SellerID string  `json:"seller_id"`
 }
+
+// NewOffer creates Offer from OfferEntry
+func NewOffer(o xdr.OfferEntry) *Offer {
+	p, _ := big.NewRat(int64(o.Price.N), int64(o.Price.D)).Float64()
+
+	return &Offer{
+		Amount:   amount.String(o.Amount),
+		Price:    p,
+		PriceND:  Price{int(o.Price.N), int(o.Price.D)},
+		Buying:   *NewAsset(&o.Buying),
+		Selling:  *NewAsset(&o.Selling),
+		OfferID:  int64(o.OfferId),
+		SellerID: o.SellerId.Address(),
+	}
+}
+
+// LiveOffer represents the latest state of an offer in the order book
+type LiveOffer struct {
+	ID string `json:"id"`
+	Offer
+	Passive            bool      `json:"passive"`
+	LastModifiedLedger int       `json:"last_modified_ledger"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Removed            bool      `json:"removed,omitempty"`
+}
+
+// NewLiveOffer creates LiveOffer from OfferEntry
+func NewLiveOffer(o xdr.OfferEntry, seq int, closeTime time.Time) *LiveOffer {
+	return &LiveOffer{
+		ID:                 strconv.FormatInt(int64(o.OfferId), 10),
+		Offer:              *NewOffer(o),
+		Passive:            xdr.OfferEntryFlags(o.Flags)&xdr.OfferEntryFlagsPassiveFlag != 0,
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+	}
+}
+
+// NewRemovedOffer creates LiveOffer representing removed offer
+func NewRemovedOffer(key xdr.LedgerKeyOffer, seq int, closeTime time.Time) *LiveOffer {
+	return &LiveOffer{
+		ID:                 strconv.FormatInt(int64(key.OfferId), 10),
+		Offer:              Offer{OfferID: int64(key.OfferId), SellerID: key.SellerId.Address()},
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+		Removed:            true,
+	}
+}
+
+// DocID returns offer id
+func (o *LiveOffer) DocID() *string {
+	return &o.ID
+}
+
+// IndexName offers index name
+func (o *LiveOffer) IndexName() IndexName {
+	return offersIndexName
+}
+
+// StateSeq returns ledger seq offer state was taken at
+func (o *LiveOffer) StateSeq() int {
+	return o.LastModifiedLedger
+}
+
+// IsRemoved returns true if offer was taken or cancelled
+func (o *LiveOffer) IsRemoved() bool {
+	return o.Removed
+}
@@ -29,11 +29,15 @@ type Operation struct {
 	TxSourceAccountID    string             `json:"tx_source_account_id"`
 	Type                 string             `json:"type"`
 	SourceAccountID      string             `json:"source_account_id,omitempty"`
+	SourceAccountMuxed   string             `json:"source_account_muxed,omitempty"`
+	SourceAccountMuxedID string             `json:"source_account_muxed_id,omitempty"`
 	SourceAsset          *Asset             `json:"source_asset,omitempty"`
 	SourceAmount         string             `json:"source_amount,omitempty"`
 	AmountReceived       string             `json:"amount_received,omitempty"`
 	AmountSent           string             `json:"amount_sent,omitempty"`
 	DestinationAccountID string             `json:"destination_account_id,omitempty"`
+	DestinationMuxed     string             `json:"destination_account_muxed,omitempty"`
+	DestinationMuxedID   string             `json:"destination_account_muxed_id,omitempty"`
 	DestinationAsset     *Asset             `json:"destination_asset,omitempty"`
 	DestinationAmount    string             `json:"destination_amount,omitempty"`
 	OfferPrice           float64            `json:"offer_price,omitempty"`
@@ -60,6 +64,9 @@ type Operation struct {
 	ResultLastDestination string `json:"result_last_destination,omitempty"`
 	ResultNoIssuer        *Asset `json:"result_no_issuer,omitempty"`
 
+	InflationPayoutsCount int    `json:"inflation_payouts_count,omitempty"`
+	InflationPayoutsTotal string `json:"inflation_payouts_total,omitempty"`
+
 	*Memo `json:"memo,omitempty"`
 }
 
@@ -92,3 +99,8 @@ func (op *Operation) DocID() *string {
 func (op *Operation) IndexName() IndexName {
 	return opIndexName
 }
+
+// PartitionKey returns close time and ledger seq the operation partition is selected by
+func (op *Operation) PartitionKey() (time.Time, int) {
+	return op.CloseTime, op.Seq
+}
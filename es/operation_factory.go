@@ -80,6 +80,7 @@ func (f *operationFactory) assignPagingToken() {
 func (f *operationFactory) assignSourceAccountID() error {
 	var err error
 	sourceAccountID := f.transaction.SourceAccountID
+	muxed, muxedID := f.transaction.SourceAccountMuxed, f.transaction.SourceAccountMuxedID
 
 	if f.source.SourceAccount != nil {
 		sourceAccountID, err = util.EncodeMuxedAccount(*f.source.SourceAccount)
@@ -87,12 +88,33 @@ func (f *operationFactory) assignSourceAccountID() error {
 		if err != nil {
 			return err
 		}
+
+		muxed, muxedID, err = util.EncodeMuxedAddress(*f.source.SourceAccount)
+
+		if err != nil {
+			return err
+		}
 	}
 
 	f.operation.SourceAccountID = sourceAccountID
+	f.operation.SourceAccountMuxed = muxed
+	f.operation.SourceAccountMuxedID = muxedID
 	return nil
 }
 
+// assignDestination sets destination G-address along with M-address and id if destination is muxed
+func (f *operationFactory) assignDestination(d xdr.MuxedAccount) error {
+	var err error
+	f.operation.DestinationAccountID, err = util.EncodeMuxedAccount(d)
+
+	if err != nil {
+		return err
+	}
+
+	f.operation.DestinationMuxed, f.operation.DestinationMuxedID, err = util.EncodeMuxedAddress(d)
+	return err
+}
+
 func (f *operationFactory) assignType() {
 	f.operation.Type = strings.Replace(f.source.Body.Type.String(), "OperationType", "", 1)
 }
@@ -141,8 +163,7 @@ func (f *operationFactory) assignCreateAccount(o xdr.CreateAccountOp) {
 func (f *operationFactory) assignPayment(o xdr.PaymentOp) error {
 	f.operation.SourceAmount = amount.String(o.Amount)
 
-	var err error
-	f.operation.DestinationAccountID, err = util.EncodeMuxedAccount(o.Destination)
+	err := f.assignDestination(o.Destination)
 
 	if err != nil {
 		return err
@@ -153,8 +174,7 @@ func (f *operationFactory) assignPayment(o xdr.PaymentOp) error {
 }
 
 func (f *operationFactory) assignPathPaymentStrictReceive(o xdr.PathPaymentStrictReceiveOp) error {
-	var err error
-	f.operation.DestinationAccountID, err = util.EncodeMuxedAccount(o.Destination)
+	err := f.assignDestination(o.Destination)
 
 	if err != nil {
 		return err
@@ -176,8 +196,7 @@ func (f *operationFactory) assignPathPaymentStrictReceive(o xdr.PathPaymentStric
 }
 
 func (f *operationFactory) assignPathPaymentStrictSend(o xdr.PathPaymentStrictSendOp) error {
-	var err error
-	f.operation.DestinationAccountID, err = util.EncodeMuxedAccount(o.Destination)
+	err := f.assignDestination(o.Destination)
 
 	if err != nil {
 		return err
@@ -251,36 +270,21 @@ func (f *operationFactory) assignAllowTrust(o xdr.AllowTrustOp) {
 	f.operation.DestinationAsset = NewAsset(&a)
 	f.operation.DestinationAccountID = o.Trustor.Address()
 
-	flags := xdr.TrustLineFlags(o.Authorize)
-
-	if flags.IsAuthorized() {
-		f.operation.Authorize = Full
-	} else if flags.IsAuthorizedToMaintainLiabilitiesFlag() {
-		f.operation.Authorize = MaintainLiabilities
-	} else {
-		f.operation.Authorize = None
-	}
+	f.operation.Authorize = NewAuthorizationFlag(xdr.TrustLineFlags(o.Authorize))
 }
 
 func (f *operationFactory) assignAccountMerge(d xdr.MuxedAccount) error {
-	var err error
-	f.operation.DestinationAccountID, err = util.EncodeMuxedAccount(d)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return f.assignDestination(d)
 }
 
 func (f *operationFactory) assignBumpSequence(o xdr.BumpSequenceOp) {
 	f.operation.BumpTo = int(o.BumpTo)
 }
 
-// TODO: Apply some magic to the value
 func (f *operationFactory) assignManageData(o xdr.ManageDataOp) {
-	f.operation.Data = &DataEntry{Name: string(o.DataName)}
 	if o.DataValue != nil {
-		f.operation.Data.Value = string(*o.DataValue)
+		f.operation.Data = NewDataEntry(o.DataName, *o.DataValue)
+	} else {
+		f.operation.Data = NewDataEntry(o.DataName, nil)
 	}
 }
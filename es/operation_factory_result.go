@@ -1,7 +1,6 @@
 package es
 
 import (
-	"math/big"
 	"strings"
 
 	"github.com/stellar/go/amount"
@@ -124,17 +123,7 @@ func (f *operationFactory) assignManageBuyOfferResult(r xdr.ManageBuyOfferResult
 
 func (f *operationFactory) assignManageOfferResult(s xdr.ManageOfferSuccessResult) {
 	if o, ok := s.Offer.GetOffer(); ok {
-		p, _ := big.NewRat(int64(o.Price.N), int64(o.Price.D)).Float64()
-
-		f.operation.ResultOffer = &Offer{
-			Amount:   amount.String(o.Amount),
-			Price:    p,
-			PriceND:  Price{int(o.Price.N), int(o.Price.D)},
-			Buying:   *NewAsset(&o.Buying),
-			Selling:  *NewAsset(&o.Selling),
-			OfferID:  int64(o.OfferId),
-			SellerID: o.SellerId.Address(),
-		}
+		f.operation.ResultOffer = NewOffer(o)
 
 		f.operation.ResultOfferEffect = strings.Replace(
 			s.Offer.Effect.String(), "ManageOfferEffectManageOffer", "", 1,
@@ -179,4 +168,15 @@ func (f *operationFactory) assignBumpSequenceResult(r xdr.BumpSequenceResult) {
 func (f *operationFactory) assignInflationResult(r xdr.InflationResult) {
 	f.operation.InnerResultCode = int(r.Code)
 	f.operation.Successful = r.Code == xdr.InflationResultCodeInflationSuccess
+
+	if payouts, ok := r.GetPayouts(); ok {
+		var total xdr.Int64
+
+		for _, payout := range payouts {
+			total += payout.Amount
+		}
+
+		f.operation.InflationPayoutsCount = len(payouts)
+		f.operation.InflationPayoutsTotal = amount.String(total)
+	}
 }
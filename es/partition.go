@@ -0,0 +1,166 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PartitionScheme represents the way documents are spread over partitions of the index
+type PartitionScheme string
+
+const (
+	// PartitionNone writes documents into a single index
+	PartitionNone PartitionScheme = "none"
+
+	// PartitionMonthly writes documents into monthly partitions by ledger close time
+	PartitionMonthly PartitionScheme = "monthly"
+
+	// PartitionLedgers writes documents into partitions by ledger ranges of fixed size
+	PartitionLedgers PartitionScheme = "ledgers"
+)
+
+// partitionedIndices are indices which may be partitioned
+var partitionedIndices = map[IndexName]bool{
+	txIndexName:      true,
+	opIndexName:      true,
+	balanceIndexName: true,
+	tradesIndexName:  true,
+}
+
+// Partitioned represents a document which may be written into partition of the index
+type Partitioned interface {
+	Indexable
+	PartitionKey() (closeTime time.Time, seq int)
+}
+
+// Partitioning represents partitioning configuration
+type Partitioning struct {
+	Scheme  PartitionScheme
+	Ledgers int
+}
+
+// Enabled returns true if documents are written into partitions
+func (p Partitioning) Enabled() bool {
+	return p.Scheme != "" && p.Scheme != PartitionNone
+}
+
+// IsPartitioned returns true if the index is split into partitions
+func (p Partitioning) IsPartitioned(name IndexName) bool {
+	return p.Enabled() && partitionedIndices[name]
+}
+
+// IndexFor returns the name of the index document is written to. Partitions are named after the
+// versioned index, e.g. op-v1-2020.05 or op-v1-000023000000, and are created from the template on
+// first write. Indices which are not partitioned are written through their aliases.
+func (p Partitioning) IndexFor(obj Indexable, alias IndexName) IndexName {
+	name := obj.IndexName()
+	doc, ok := obj.(Partitioned)
+
+	if !ok || !p.IsPartitioned(name) {
+		return alias
+	}
+
+	prefix := VersionedIndexName(alias, CurrentIndexVersion(name))
+	closeTime, seq := doc.PartitionKey()
+
+	if p.Scheme == PartitionMonthly {
+		return IndexName(fmt.Sprintf("%s-%s", prefix, closeTime.UTC().Format("2006.01")))
+	}
+
+	return IndexName(fmt.Sprintf("%s-%012d", prefix, seq/p.Ledgers*p.Ledgers))
+}
+
+// partitionMeta is stored in _meta of partition template mappings to tell which partitioning the
+// template was created for
+type partitionMeta struct {
+	Scheme  PartitionScheme `json:"partition"`
+	Ledgers int             `json:"partition_ledgers,omitempty"`
+}
+
+// meta returns partitioning stored in templates, ledger range size matters for ledgers scheme only
+func (p Partitioning) meta() partitionMeta {
+	if p.Scheme != PartitionLedgers {
+		return partitionMeta{Scheme: p.Scheme}
+	}
+
+	return partitionMeta{Scheme: p.Scheme, Ledgers: p.Ledgers}
+}
+
+// CheckPartitioning stops if partition templates of the cluster do not match the partitioning. Writing
+// partitioned indices without partitioning would target aliases spanning several partitions, which
+// fails every bulk item, writing partitions without templates would create them without mappings and
+// writing with another scheme would spread the same documents over differently named partitions.
+func CheckPartitioning(adapter Adapter, p Partitioning) {
+	for name := range partitionedIndices {
+		template := VersionedIndexName(adapter.Indices().Name(name), CurrentIndexVersion(name))
+		def, exists := adapter.GetTemplate(template)
+
+		if p.IsPartitioned(name) && !exists {
+			log.Fatalf("%s template not found, run create-index --partition=%s first", template, p.Scheme)
+		}
+
+		if !p.IsPartitioned(name) && exists {
+			log.Fatalf("%s index is partitioned, pass the --partition value create-index was run with", name)
+		}
+
+		if !exists {
+			continue
+		}
+
+		if actual := templatePartitioning(def); actual != p.meta() {
+			log.Fatalf(
+				"%s template partitions by %s (%d ledgers), while --partition=%s (%d ledgers) is given, "+
+					"pass the values create-index was run with",
+				template, actual.Scheme, actual.Ledgers, p.Scheme, p.meta().Ledgers,
+			)
+		}
+	}
+}
+
+// templatePartitioning returns partitioning stored in the template, empty for templates created
+// without it
+func templatePartitioning(def IndexDefinition) partitionMeta {
+	var template struct {
+		Mappings struct {
+			Meta partitionMeta `json:"_meta"`
+		} `json:"mappings"`
+	}
+
+	if err := json.Unmarshal([]byte(def), &template); err != nil {
+		log.Fatalf("Error parsing index template: %s", err)
+	}
+
+	return template.Mappings.Meta
+}
+
+// PartitionTemplate returns index template applied to partitions of the versioned index, every
+// partition joins the read alias. Partitions are written by their names rather than through a write
+// alias, since documents go to the partition of their ledger, not to the latest one. Partitioning
+// is stored in mappings _meta to be checked by CheckPartitioning.
+func PartitionTemplate(alias IndexName, target IndexName, def IndexDefinition, p Partitioning) IndexDefinition {
+	var template map[string]interface{}
+
+	if err := json.Unmarshal([]byte(def), &template); err != nil {
+		log.Fatalf("Error parsing %s index definition: %s", alias, err)
+	}
+
+	mappings, ok := template["mappings"].(map[string]interface{})
+	if !ok {
+		mappings = make(map[string]interface{})
+		template["mappings"] = mappings
+	}
+
+	mappings["_meta"] = p.meta()
+
+	template["index_patterns"] = []string{string(target) + "-*"}
+	template["aliases"] = map[string]interface{}{string(alias): map[string]interface{}{}}
+
+	body, err := json.Marshal(template)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return IndexDefinition(body)
+}
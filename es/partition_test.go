@@ -0,0 +1,30 @@
+package es
+
+import (
+	"testing"
+)
+
+func TestPartitionTemplateStoresPartitioning(t *testing.T) {
+	def := GetIndexDefinitions()[opIndexName]
+
+	for _, p := range []Partitioning{
+		{Scheme: PartitionMonthly, Ledgers: 1000000},
+		{Scheme: PartitionLedgers, Ledgers: 1000000},
+	} {
+		template := PartitionTemplate("op", "op-v2", def, p)
+
+		if actual := templatePartitioning(template); actual != p.meta() {
+			t.Errorf("template of %+v stores %+v", p, actual)
+		}
+	}
+
+	monthly := templatePartitioning(PartitionTemplate("op", "op-v2", def, Partitioning{Scheme: PartitionMonthly}))
+
+	if monthly == (Partitioning{Scheme: PartitionLedgers, Ledgers: 1000000}).meta() {
+		t.Error("monthly template matches ledgers partitioning")
+	}
+
+	if templatePartitioning(def) == monthly {
+		t.Error("template without partitioning matches monthly partitioning")
+	}
+}
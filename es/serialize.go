@@ -7,10 +7,27 @@ import (
 	"log"
 )
 
-// SerializeForBulk returns object serialized for elastic bulk indexing
-func SerializeForBulk(obj Indexable, b *bytes.Buffer) {
+// BulkAction represents ElasticSearch bulk action used to write documents
+type BulkAction string
+
+const (
+	// BulkActionIndex overwrites existing documents with the same id
+	BulkActionIndex BulkAction = "index"
+
+	// BulkActionCreate skips documents which already exist
+	BulkActionCreate BulkAction = "create"
+)
+
+// SerializeForBulk returns object serialized for elastic bulk indexing into the given index
+func SerializeForBulk(obj Indexable, index IndexName, action BulkAction, b *bytes.Buffer) {
+	if state, ok := obj.(StateDocument); ok {
+		serializeStateForBulk(state, index, b)
+		return
+	}
+
 	meta := fmt.Sprintf(
-		`{ "index": { "_index": "%s", "_type": "_doc" } }%s`, obj.IndexName(), "\n",
+		`{ "%s": { "_index": "%s", "_type": "_doc", "_id": "%s" } }%s`,
+		action, index, *obj.DocID(), "\n",
 	)
 
 	data, err := json.Marshal(obj)
@@ -24,3 +41,33 @@ func SerializeForBulk(obj Indexable, b *bytes.Buffer) {
 	b.Write([]byte(meta))
 	b.Write(data)
 }
+
+// serializeStateForBulk writes state document versioned by ledger seq. Removed entries are deleted with
+// the version of the removal ledger, ElasticSearch keeps the version of deleted document for
+// index.gc_deletes, so older state exported within that period does not bring the entry back.
+func serializeStateForBulk(obj StateDocument, index IndexName, b *bytes.Buffer) {
+	if obj.IsRemoved() {
+		meta := fmt.Sprintf(
+			`{ "delete": { "_index": "%s", "_type": "_doc", "_id": "%s", "version": %d, "version_type": "external" } }%s`,
+			index, *obj.DocID(), obj.StateSeq(), "\n",
+		)
+
+		b.Write([]byte(meta))
+		return
+	}
+
+	meta := fmt.Sprintf(
+		`{ "index": { "_index": "%s", "_type": "_doc", "_id": "%s", "version": %d, "version_type": "external_gte" } }%s`,
+		index, *obj.DocID(), obj.StateSeq(), "\n",
+	)
+
+	b.Write([]byte(meta))
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b.Write(data)
+	b.Write([]byte("\n"))
+}
@@ -0,0 +1,26 @@
+package es
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/stellar/go/xdr"
+)
+
+// Signature represents decorated signature of the transaction envelope
+type Signature struct {
+	Hint      string `json:"hint"`
+	Signature string `json:"signature"`
+}
+
+// NewSignatures returns hex encoded hints and base64 encoded signatures
+func NewSignatures(signatures []xdr.DecoratedSignature) (result []Signature) {
+	for _, s := range signatures {
+		result = append(result, Signature{
+			Hint:      hex.EncodeToString(s.Hint[:]),
+			Signature: base64.StdEncoding.EncodeToString(s.Signature),
+		})
+	}
+
+	return result
+}
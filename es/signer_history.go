@@ -2,50 +2,165 @@ package es
 
 import (
 	"time"
+
+	"github.com/stellar/go/xdr"
 )
 
-// SignerHistory represents signer change entry, still the question if it is required
+// SignerAction represents the kind of signer change
+type SignerAction string
+
+const (
+	// SignerAdded marks signer added to the account, including master key of created account
+	SignerAdded SignerAction = "added"
+
+	// SignerUpdated marks signer weight change
+	SignerUpdated SignerAction = "updated"
+
+	// SignerRemoved marks signer removed from the account, including signers of merged account
+	SignerRemoved SignerAction = "removed"
+)
+
+// SignerHistory represents signer change entry
 type SignerHistory struct {
-	ID              string      `json:"id"`
-	PagingToken     PagingToken `json:"paging_token"`
-	AccountID       string      `json:"account_id"`
-	Signer          string      `json:"signer"`
-	Type            int         `json:"type"`
-	Weight          int         `json:"weight"`
-	TxIndex         int         `json:"tx_idx"`
-	Index           int         `json:"idx"`
-	Seq             int         `json:"seq"`
-	LedgerCloseTime time.Time   `json:"ledger_close_time"`
+	ID              string       `json:"id"`
+	PagingToken     PagingToken  `json:"paging_token"`
+	AccountID       string       `json:"account_id"`
+	Signer          string       `json:"signer"`
+	Type            int          `json:"type"`
+	Weight          int          `json:"weight"`
+	PrevWeight      int          `json:"prev_weight"`
+	Action          SignerAction `json:"action"`
+	TxIndex         int          `json:"tx_idx"`
+	Index           int          `json:"idx"`
+	Seq             int          `json:"seq"`
+	LedgerCloseTime time.Time    `json:"ledger_close_time"`
 }
 
-// ProduceSignerHistory creates new signer history entry
-func ProduceSignerHistory(o *Operation) (h *SignerHistory) {
-	s := o.Signer
+// signerHistoryExtractor compares account signers before and after every change
+type signerHistoryExtractor struct {
+	closeTime   time.Time
+	pagingToken PagingToken
+	tokenIndex  int
+
+	states  map[string]xdr.AccountEntry
+	entries []*SignerHistory
+}
 
-	if s == nil {
-		return nil
+// ProduceSignerHistory returns signer changes made to accounts by the set of ledger entry changes,
+// master key weight is tracked as the signer having account id as a key
+func ProduceSignerHistory(changes xdr.LedgerEntryChanges, closeTime time.Time, pagingToken PagingToken, startIndex int) []*SignerHistory {
+	e := &signerHistoryExtractor{
+		closeTime:   closeTime,
+		pagingToken: pagingToken,
+		tokenIndex:  startIndex,
+		states:      make(map[string]xdr.AccountEntry),
 	}
 
-	token := PagingToken{
-		LedgerSeq:        o.Seq,
-		TransactionOrder: o.TxIndex,
-		OperationOrder:   o.Index,
+	for _, change := range changes {
+		switch change.Type {
+		case xdr.LedgerEntryChangeTypeLedgerEntryState:
+			if account, ok := change.MustState().Data.GetAccount(); ok {
+				e.states[account.AccountId.Address()] = account
+			}
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+			if account, ok := change.MustCreated().Data.GetAccount(); ok {
+				e.compare(account.AccountId.Address(), nil, &account)
+			}
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+			if account, ok := change.MustUpdated().Data.GetAccount(); ok {
+				address := account.AccountId.Address()
+
+				if state, ok := e.states[address]; ok {
+					e.compare(address, &state, &account)
+				}
+
+				e.states[address] = account
+			}
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			if key, ok := change.MustRemoved().GetAccount(); ok {
+				address := key.AccountId.Address()
+
+				if state, ok := e.states[address]; ok {
+					e.compare(address, &state, nil)
+				}
+			}
+		}
 	}
 
-	entry := &SignerHistory{
+	return e.entries
+}
+
+func (e *signerHistoryExtractor) compare(address string, before *xdr.AccountEntry, after *xdr.AccountEntry) {
+	prev := accountSigners(before)
+	next := accountSigners(after)
+
+	for _, s := range next {
+		p, ok := prev.find(s.ID)
+
+		if !ok {
+			e.add(address, s, 0, SignerAdded)
+		} else if p.Weight != s.Weight {
+			e.add(address, s, p.Weight, SignerUpdated)
+		}
+	}
+
+	for _, p := range prev {
+		if _, ok := next.find(p.ID); !ok {
+			e.add(address, Signer{ID: p.ID, Type: p.Type}, p.Weight, SignerRemoved)
+		}
+	}
+}
+
+func (e *signerHistoryExtractor) add(address string, s Signer, prevWeight int, action SignerAction) {
+	e.tokenIndex++
+	token := PagingToken{EffectIndex: e.tokenIndex}.Merge(e.pagingToken)
+
+	e.entries = append(e.entries, &SignerHistory{
 		ID:              token.String(),
 		PagingToken:     token,
-		AccountID:       o.SourceAccountID,
-		Signer:          o.Signer.ID,
-		Type:            o.Signer.Type,
-		Weight:          o.Signer.Weight,
-		TxIndex:         o.TxIndex,
-		Index:           o.Index,
-		Seq:             o.Seq,
-		LedgerCloseTime: o.CloseTime,
+		AccountID:       address,
+		Signer:          s.ID,
+		Type:            s.Type,
+		Weight:          s.Weight,
+		PrevWeight:      prevWeight,
+		Action:          action,
+		TxIndex:         token.TransactionOrder,
+		Index:           token.OperationOrder,
+		Seq:             token.LedgerSeq,
+		LedgerCloseTime: e.closeTime,
+	})
+}
+
+type signerList []Signer
+
+func (l signerList) find(id string) (Signer, bool) {
+	for _, signer := range l {
+		if signer.ID == id {
+			return signer, true
+		}
+	}
+
+	return Signer{}, false
+}
+
+// accountSigners returns account signers along with the master key, master key with zero weight is omitted
+func accountSigners(a *xdr.AccountEntry) (signers signerList) {
+	if a == nil {
+		return signers
+	}
+
+	if w := int(a.Thresholds.MasterKeyWeight()); w > 0 {
+		signers = append(signers, Signer{ID: a.AccountId.Address(), Weight: w, Type: int(xdr.SignerKeyTypeSignerKeyTypeEd25519)})
+	}
+
+	for i := range a.Signers {
+		signers = append(signers, *NewSigner(&a.Signers[i]))
 	}
 
-	return entry
+	return signers
 }
 
 // DocID balance es document id
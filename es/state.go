@@ -0,0 +1,95 @@
+package es
+
+import (
+	"time"
+
+	"github.com/stellar/go/xdr"
+)
+
+// StateDocument represents the latest state of a ledger entry. It is written with the ledger seq as the
+// external version, so exporting older ledgers never overwrites newer state. Removed entries are deleted
+// from the index.
+type StateDocument interface {
+	Indexable
+	StateSeq() int
+	IsRemoved() bool
+}
+
+// stateExtractor collects the latest state of ledger entries changed within a ledger
+type stateExtractor struct {
+	seq       int
+	closeTime time.Time
+
+	documents map[string]StateDocument
+	keys      []string
+}
+
+func newStateExtractor(seq int, closeTime time.Time) *stateExtractor {
+	return &stateExtractor{
+		seq:       seq,
+		closeTime: closeTime,
+		documents: make(map[string]StateDocument),
+	}
+}
+
+// apply walks through the changes, changes must be applied in the order they happened
+func (e *stateExtractor) apply(changes xdr.LedgerEntryChanges) {
+	for _, change := range changes {
+		switch change.Type {
+		case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+			e.entry(change.MustCreated())
+		case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+			e.entry(change.MustUpdated())
+		case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			e.removed(change.MustRemoved())
+		}
+	}
+}
+
+func (e *stateExtractor) entry(entry xdr.LedgerEntry) {
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		e.put(NewAccount(entry.Data.MustAccount(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeOffer:
+		e.put(NewLiveOffer(entry.Data.MustOffer(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeTrustline:
+		e.put(NewTrustline(entry.Data.MustTrustLine(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeData:
+		e.put(NewAccountData(entry.Data.MustData(), e.seq, e.closeTime))
+	}
+}
+
+func (e *stateExtractor) removed(key xdr.LedgerKey) {
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := key.MustAccount()
+		e.put(NewRemovedAccount(account.AccountId.Address(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeOffer:
+		e.put(NewRemovedOffer(key.MustOffer(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeTrustline:
+		e.put(NewRemovedTrustline(key.MustTrustLine(), e.seq, e.closeTime))
+	case xdr.LedgerEntryTypeData:
+		e.put(NewRemovedAccountData(key.MustData(), e.seq, e.closeTime))
+	}
+}
+
+func (e *stateExtractor) put(doc StateDocument) {
+	key := string(doc.IndexName()) + ":" + *doc.DocID()
+
+	if _, ok := e.documents[key]; !ok {
+		e.keys = append(e.keys, key)
+	}
+
+	e.documents[key] = doc
+}
+
+// result returns the latest states in the order entries were changed first
+func (e *stateExtractor) result() []StateDocument {
+	docs := make([]StateDocument, len(e.keys))
+
+	for i, key := range e.keys {
+		docs[i] = e.documents[key]
+	}
+
+	return docs
+}
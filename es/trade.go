@@ -29,3 +29,8 @@ func (t *Trade) DocID() *string {
 func (t *Trade) IndexName() IndexName {
 	return tradesIndexName
 }
+
+// PartitionKey returns close time and ledger seq the trade partition is selected by
+func (t *Trade) PartitionKey() (time.Time, int) {
+	return t.LedgerCloseTime, t.PagingToken.LedgerSeq
+}
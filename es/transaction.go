@@ -1,9 +1,12 @@
 package es
 
 import (
+	"encoding/hex"
 	"time"
 
-	"github.com/astroband/astrologer/db"
+	"github.com/astroband/astrologer/source"
+	"github.com/astroband/astrologer/util"
+	"github.com/stellar/go/network"
 	"github.com/stellar/go/xdr"
 )
 
@@ -22,12 +25,35 @@ type Transaction struct {
 	ResultCode      int         `json:"result_code"`
 	SourceAccountID string      `json:"source_account_id"`
 
+	SourceAccountMuxed   string `json:"source_account_muxed,omitempty"`
+	SourceAccountMuxedID string `json:"source_account_muxed_id,omitempty"`
+	FeeAccountMuxed      string `json:"fee_account_muxed,omitempty"`
+	FeeAccountMuxedID    string `json:"fee_account_muxed_id,omitempty"`
+
 	*TimeBounds `json:"time_bounds,omitempty"`
 	*Memo       `json:"memo,omitempty"`
+
+	Signatures       []Signature       `json:"signatures,omitempty"`
+	InnerTransaction *InnerTransaction `json:"inner_transaction,omitempty"`
+
+	EnvelopeXdr   string `json:"envelope_xdr,omitempty"`
+	ResultXdr     string `json:"result_xdr,omitempty"`
+	ResultMetaXdr string `json:"result_meta_xdr,omitempty"`
+	FeeMetaXdr    string `json:"fee_meta_xdr,omitempty"`
 }
 
-// NewTransaction creates LedgerHeader from LedgerHeaderRow
-func (s *ledgerSerializer) NewTransaction(row *db.TxHistoryRow, t time.Time) (*Transaction, error) {
+// InnerTransaction represents the transaction wrapped into fee bump envelope
+type InnerTransaction struct {
+	ID         string      `json:"id"`
+	MaxFee     int         `json:"max_fee"`
+	FeeCharged int         `json:"fee_charged"`
+	Successful bool        `json:"successful"`
+	ResultCode int         `json:"result_code"`
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+// NewTransaction creates Transaction from source transaction
+func (s *ledgerSerializer) NewTransaction(row *source.Transaction, t time.Time) (*Transaction, error) {
 	var (
 		err      error
 		envelope = row.Envelope
@@ -49,11 +75,11 @@ func (s *ledgerSerializer) NewTransaction(row *db.TxHistoryRow, t time.Time) (*T
 	}
 
 	transaction := &Transaction{
-		ID:              row.ID,
+		ID:              row.Hash,
 		Index:           row.Index,
-		Seq:             row.LedgerSeq,
+		Seq:             s.ledger.Seq,
 		MaxFee:          int(envelope.Fee()),
-		PagingToken:     PagingToken{LedgerSeq: row.LedgerSeq, TransactionOrder: row.Index},
+		PagingToken:     PagingToken{LedgerSeq: s.ledger.Seq, TransactionOrder: row.Index},
 		FeeCharged:      int(row.Result.Result.FeeCharged),
 		CloseTime:       t,
 		Successful:      success,
@@ -62,6 +88,12 @@ func (s *ledgerSerializer) NewTransaction(row *db.TxHistoryRow, t time.Time) (*T
 		SourceAccountID: sourceAccountAddress,
 	}
 
+	transaction.SourceAccountMuxed, transaction.SourceAccountMuxedID, err = util.EncodeMuxedAddress(envelope.SourceAccount())
+
+	if err != nil {
+		return nil, err
+	}
+
 	if envelope.IsFeeBump() {
 		feeSourceAccountId := envelope.FeeBumpAccount().ToAccountId()
 		feeSourceAddress, err := (&feeSourceAccountId).GetAddress()
@@ -71,7 +103,23 @@ func (s *ledgerSerializer) NewTransaction(row *db.TxHistoryRow, t time.Time) (*T
 		}
 
 		transaction.FeeAccountID = feeSourceAddress
+		transaction.FeeAccountMuxed, transaction.FeeAccountMuxedID, err = util.EncodeMuxedAddress(envelope.FeeBumpAccount())
+
+		if err != nil {
+			return nil, err
+		}
+
 		transaction.MaxFee = int(envelope.FeeBumpFee())
+		transaction.Signatures = NewSignatures(envelope.FeeBump.Signatures)
+		transaction.InnerTransaction, err = newInnerTransaction(envelope.FeeBump, row.Result.Result, s.options.NetworkPassphrase)
+
+		if err != nil {
+			return nil, err
+		}
+	} else if envelope.Type == xdr.EnvelopeTypeEnvelopeTypeTxV0 {
+		transaction.Signatures = NewSignatures(envelope.V0.Signatures)
+	} else {
+		transaction.Signatures = NewSignatures(envelope.V1.Signatures)
 	}
 
 	if envelope.Memo().Type != xdr.MemoTypeMemoNone {
@@ -88,9 +136,60 @@ func (s *ledgerSerializer) NewTransaction(row *db.TxHistoryRow, t time.Time) (*T
 		}
 	}
 
+	if s.options.RawXdr {
+		err = transaction.assignRawXdr(row)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return transaction, nil
 }
 
+// newInnerTransaction returns inner transaction details, result fields are taken from the inner result pair
+// and left empty when fee bump failed before the inner transaction was applied
+func newInnerTransaction(envelope *xdr.FeeBumpTransactionEnvelope, result xdr.TransactionResult, passphrase string) (*InnerTransaction, error) {
+	inner := envelope.Tx.InnerTx.V1
+
+	hash, err := network.HashTransaction(inner.Tx, passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &InnerTransaction{
+		ID:         hex.EncodeToString(hash[:]),
+		MaxFee:     int(inner.Tx.Fee),
+		Signatures: NewSignatures(inner.Signatures),
+	}
+
+	if pair, ok := result.Result.GetInnerResultPair(); ok {
+		tx.FeeCharged = int(pair.Result.FeeCharged)
+		tx.ResultCode = int(pair.Result.Result.Code)
+		tx.Successful = pair.Result.Result.Code == xdr.TransactionResultCodeTxSuccess
+	}
+
+	return tx, nil
+}
+
+func (tx *Transaction) assignRawXdr(row *source.Transaction) (err error) {
+	if tx.EnvelopeXdr, err = xdr.MarshalBase64(row.Envelope); err != nil {
+		return err
+	}
+
+	if tx.ResultXdr, err = xdr.MarshalBase64(row.Result.Result); err != nil {
+		return err
+	}
+
+	if tx.ResultMetaXdr, err = xdr.MarshalBase64(row.Meta); err != nil {
+		return err
+	}
+
+	tx.FeeMetaXdr, err = xdr.MarshalBase64(row.FeeChanges)
+	return err
+}
+
 // DocID return es transaction id (tx id in this case)
 func (tx *Transaction) DocID() *string {
 	return &tx.ID
@@ -100,3 +199,8 @@ func (tx *Transaction) DocID() *string {
 func (tx *Transaction) IndexName() IndexName {
 	return txIndexName
 }
+
+// PartitionKey returns close time and ledger seq the transaction partition is selected by
+func (tx *Transaction) PartitionKey() (time.Time, int) {
+	return tx.CloseTime, tx.Seq
+}
@@ -0,0 +1,55 @@
+package es
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+func TestInnerTransactionHashWithoutInnerResult(t *testing.T) {
+	inner := xdr.TransactionV1Envelope{
+		Tx: xdr.Transaction{
+			SourceAccount: xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &xdr.Uint256{}},
+			Fee:           100,
+			SeqNum:        1,
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+		},
+	}
+
+	envelope := &xdr.FeeBumpTransactionEnvelope{
+		Tx: xdr.FeeBumpTransaction{
+			Fee: 200,
+			InnerTx: xdr.FeeBumpTransactionInnerTx{
+				Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+				V1:   &inner,
+			},
+		},
+	}
+
+	result := xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxInsufficientFee},
+	}
+
+	tx, err := newInnerTransaction(envelope, result, network.TestNetworkPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := network.HashTransactionInEnvelope(
+		xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: &inner},
+		network.TestNetworkPassphrase,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.ID != hex.EncodeToString(hash[:]) {
+		t.Errorf("expected inner hash %x, got %s", hash, tx.ID)
+	}
+
+	if tx.MaxFee != 100 || tx.FeeCharged != 0 || tx.Successful {
+		t.Errorf("unexpected inner transaction %+v", tx)
+	}
+}
@@ -0,0 +1,97 @@
+package es
+
+import (
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/xdr"
+)
+
+// Trustline represents the latest state of an account trustline
+type Trustline struct {
+	ID                 string            `json:"id"`
+	AccountID          string            `json:"account_id"`
+	Asset              Asset             `json:"asset"`
+	Balance            string            `json:"balance,omitempty"`
+	Limit              string            `json:"limit,omitempty"`
+	BuyingLiabilities  string            `json:"buying_liabilities,omitempty"`
+	SellingLiabilities string            `json:"selling_liabilities,omitempty"`
+	Authorization      AuthorizationFlag `json:"authorization"`
+	LastModifiedLedger int               `json:"last_modified_ledger"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	Removed            bool              `json:"removed,omitempty"`
+}
+
+// NewTrustline creates Trustline from TrustLineEntry
+func NewTrustline(t xdr.TrustLineEntry, seq int, closeTime time.Time) *Trustline {
+	line := &Trustline{
+		AccountID:          t.AccountId.Address(),
+		Asset:              *NewAsset(&t.Asset),
+		Balance:            amount.String(t.Balance),
+		Limit:              amount.String(t.Limit),
+		BuyingLiabilities:  amount.String(0),
+		SellingLiabilities: amount.String(0),
+		Authorization:      NewAuthorizationFlag(xdr.TrustLineFlags(t.Flags)),
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+	}
+
+	if v1, ok := t.Ext.GetV1(); ok {
+		line.BuyingLiabilities = amount.String(v1.Liabilities.Buying)
+		line.SellingLiabilities = amount.String(v1.Liabilities.Selling)
+	}
+
+	line.ID = trustlineID(line.AccountID, &line.Asset)
+
+	return line
+}
+
+// NewRemovedTrustline creates Trustline representing removed trustline
+func NewRemovedTrustline(key xdr.LedgerKeyTrustLine, seq int, closeTime time.Time) *Trustline {
+	line := &Trustline{
+		AccountID:          key.AccountId.Address(),
+		Asset:              *NewAsset(&key.Asset),
+		LastModifiedLedger: seq,
+		UpdatedAt:          closeTime,
+		Removed:            true,
+	}
+
+	line.ID = trustlineID(line.AccountID, &line.Asset)
+
+	return line
+}
+
+// NewAuthorizationFlag returns authorization level of trustline flags
+func NewAuthorizationFlag(flags xdr.TrustLineFlags) AuthorizationFlag {
+	if flags.IsAuthorized() {
+		return Full
+	} else if flags.IsAuthorizedToMaintainLiabilitiesFlag() {
+		return MaintainLiabilities
+	}
+
+	return None
+}
+
+func trustlineID(accountID string, asset *Asset) string {
+	return accountID + "-" + asset.ID
+}
+
+// DocID returns account id and asset id pair
+func (t *Trustline) DocID() *string {
+	return &t.ID
+}
+
+// IndexName trustlines index name
+func (t *Trustline) IndexName() IndexName {
+	return trustlinesIndexName
+}
+
+// StateSeq returns ledger seq trustline state was taken at
+func (t *Trustline) StateSeq() int {
+	return t.LastModifiedLedger
+}
+
+// IsRemoved returns true if trustline was removed
+func (t *Trustline) IsRemoved() bool {
+	return t.Removed
+}
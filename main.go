@@ -1,10 +1,16 @@
 package main
 
 import (
+	"log"
+
+	"github.com/astroband/astrologer/archive"
 	cmd "github.com/astroband/astrologer/commands"
 	cfg "github.com/astroband/astrologer/config"
 	"github.com/astroband/astrologer/db"
 	"github.com/astroband/astrologer/es"
+	"github.com/astroband/astrologer/metastream"
+	"github.com/astroband/astrologer/sink"
+	"github.com/astroband/astrologer/source"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -12,7 +18,7 @@ func main() {
 	kingpin.Version(cfg.Version)
 	commandName := kingpin.Parse()
 
-	esClient := es.Connect((*cfg.EsURL).String())
+	esClient := es.Connect((*cfg.EsURL).String(), indexConfig())
 
 	var command cmd.Command
 
@@ -21,24 +27,134 @@ func main() {
 		dbClient := db.Connect(*cfg.DatabaseURL)
 		command = &cmd.StatsCommand{ES: esClient, DB: dbClient}
 	case "create-index":
-		config := cmd.CreateIndexCommandConfig{Force: *cfg.ForceRecreateIndexes}
+		config := cmd.CreateIndexCommandConfig{Force: *cfg.ForceRecreateIndexes, Partition: partitioning()}
 		command = &cmd.CreateIndexCommand{ES: esClient, Config: config}
+	case "migrate":
+		config := cmd.MigrateCommandConfig{
+			Indices:    *cfg.MigrateIndexes,
+			DeleteOld:  *cfg.MigrateDeleteOld,
+			Partition:  partitioning(),
+			CursorName: *cfg.MigrateCursorName,
+		}
+		command = &cmd.MigrateCommand{ES: esClient, Config: config}
+	case "check-mappings":
+		config := cmd.CheckMappingsCommandConfig{Live: *cfg.CheckMappingsLive}
+		command = &cmd.CheckMappingsCommand{ES: esClient, Config: config}
+	case "create-tables":
+		config := cmd.CreateTablesCommandConfig{Force: *cfg.ForceRecreateTables}
+		command = &cmd.CreateTablesCommand{Postgres: connectPostgres(), Config: config}
 	case "export":
-		dbClient := db.Connect(*cfg.DatabaseURL)
+		ledgerSource := connectSource()
+		requireCount(ledgerSource, *cfg.Count)
 		config := cmd.ExportCommandConfig{
-			Start:      *cfg.Start,
-			Count:      *cfg.Count,
-			DryRun:     *cfg.ExportDryRun,
-			RetryCount: *cfg.Retries,
-			BatchSize:  *cfg.BatchSize,
+			Start:     *cfg.Start,
+			Count:     *cfg.Count,
+			DryRun:    *cfg.ExportDryRun,
+			BatchSize: *cfg.BatchSize,
+			RawXdr:    *cfg.RawXdr,
+
+			NetworkPassphrase: *cfg.NetworkPassphrase,
 		}
-		command = &cmd.ExportCommand{ES: esClient, DB: dbClient, Config: config}
+		output := connectSink(esClient, *cfg.Retries)
+		command = &cmd.ExportCommand{Source: ledgerSource, Sink: output, Config: config}
 	case "ingest":
-		dbClient := db.Connect(*cfg.DatabaseURL)
-		command = &cmd.IngestCommand{ES: esClient, DB: dbClient}
+		ledgerSource := connectSource()
+		config := cmd.IngestCommandConfig{
+			Start:      *cfg.StartIngest,
+			UseCursor:  *cfg.IngestUseCursor,
+			CursorName: *cfg.IngestCursorName,
+			RawXdr:     *cfg.RawXdr,
+
+			NetworkPassphrase: *cfg.NetworkPassphrase,
+		}
+		output := connectSink(esClient, cmd.IngestRetries)
+		command = &cmd.IngestCommand{Source: ledgerSource, Sink: output, Config: config}
+	case "fill-gaps":
+		if *cfg.Sink != "es" {
+			log.Fatal("fill-gaps looks for ledgers missing in ElasticSearch, other sinks are not supported")
+		}
+
+		ledgerSource := connectSource()
+		requireCount(ledgerSource, *cfg.FillGapsCount)
+		config := cmd.ExportCommandConfig{
+			Start:     *cfg.FillGapsStart,
+			Count:     *cfg.FillGapsCount,
+			DryRun:    *cfg.FillGapsDryRun,
+			BatchSize: *cfg.FillGapsBatchSize,
+			RawXdr:    *cfg.RawXdr,
+
+			NetworkPassphrase: *cfg.NetworkPassphrase,
+		}
+		output := connectSink(esClient, *cfg.FillGapsRetries)
+		command = &cmd.FillGapsCommand{ES: esClient, Source: ledgerSource, Sink: output, Config: config}
 	case "es-stats":
 		command = &cmd.EsStatsCommand{ES: esClient}
 	}
 
 	command.Execute()
 }
+
+// connectSource returns the ledger source selected by --source flag
+func connectSource() source.LedgerSource {
+	switch *cfg.Source {
+	case "archive":
+		return archive.Connect(*cfg.ArchiveURL, *cfg.NetworkPassphrase)
+	case "meta":
+		return metastream.Open(*cfg.MetaStream, *cfg.NetworkPassphrase)
+	}
+
+	return db.Connect(*cfg.DatabaseURL)
+}
+
+// requireCount stops if ledger count is not given for the source which does not know its last ledger
+// in advance, otherwise only the ledgers read so far would be exported
+func requireCount(ledgerSource source.LedgerSource, count int) {
+	if stream, ok := ledgerSource.(*metastream.Client); ok && stream.Sequential() && count == 0 {
+		log.Fatal("Ledger count is required to read the meta stream from a named pipe or stdin")
+	}
+}
+
+// connectSink returns the output backend selected by --sink flag
+func connectSink(esClient es.Adapter, retries int) sink.Sink {
+	switch *cfg.Sink {
+	case "ndjson":
+		return sink.NewNDJSON(*cfg.NDJSONDir, *cfg.NDJSONRotate)
+	case "postgres":
+		return connectPostgres()
+	}
+
+	partition := partitioning()
+	es.CheckPartitioning(esClient, partition)
+
+	return &sink.Elastic{
+		ES:         esClient,
+		Action:     es.BulkAction(*cfg.BulkAction),
+		RetryCount: retries,
+		Partition:  partition,
+	}
+}
+
+// indexConfig returns index names and settings from --index-config file, --index-prefix takes precedence
+func indexConfig() es.IndexConfig {
+	config := es.LoadIndexConfig(*cfg.IndexConfig)
+
+	if *cfg.IndexPrefix != "" {
+		config.Prefix = *cfg.IndexPrefix
+	}
+
+	return config
+}
+
+// partitioning returns the partitioning scheme selected by --partition flag
+func partitioning() es.Partitioning {
+	if *cfg.Partition == "ledgers" && *cfg.PartitionLedgers <= 0 {
+		log.Fatal("--partition-ledgers must be positive")
+	}
+
+	return es.Partitioning{Scheme: es.PartitionScheme(*cfg.Partition), Ledgers: *cfg.PartitionLedgers}
+}
+
+// connectPostgres returns the Postgres sink, --bulk-action=create keeps existing rows untouched
+func connectPostgres() *sink.Postgres {
+	return sink.NewPostgres(*cfg.SinkDatabaseURL, *cfg.BulkAction == "index")
+}
@@ -0,0 +1,144 @@
+package metastream
+
+import (
+	"log"
+
+	"github.com/astroband/astrologer/source"
+)
+
+// FirstLedgerSeq returns seqnum of the first ledger in the stream
+func (c *Client) FirstLedgerSeq() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.seekable {
+		c.peek()
+	}
+
+	return c.first
+}
+
+// Sequential returns true if the stream is a named pipe or stdin which can not be read ahead
+func (c *Client) Sequential() bool {
+	return !c.seekable
+}
+
+// LastLedgerSeq returns seqnum of the last ledger in the file, or the last ledger read from the pipe so far
+func (c *Client) LastLedgerSeq() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.seekable {
+		c.scan()
+	} else {
+		c.peek()
+	}
+
+	return c.last
+}
+
+// LedgerCount returns total ledgers count within given range
+func (c *Client) LedgerCount(first, last int) int {
+	return len(c.LedgerSeqsInRange(first, last))
+}
+
+// LedgerSeqsInRange returns seqnums of ledgers within given range, pipes are assumed to be contiguous
+func (c *Client) LedgerSeqsInRange(first, last int) []int {
+	seqs := []int{}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.seekable && (last == 0 || last > c.last) {
+		c.scan()
+	}
+
+	if last == 0 || (c.seekable && last > c.last) {
+		last = c.last
+	}
+
+	for seq := first; seq <= last; seq++ {
+		if _, ok := c.offsets[seq]; ok || !c.seekable {
+			seqs = append(seqs, seq)
+		}
+	}
+
+	return seqs
+}
+
+// LedgerRange returns ledgers within given range
+func (c *Client) LedgerRange(first, last int) []source.LedgerCloseData {
+	ledgers := []source.LedgerCloseData{}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.seekable {
+		for seq := first; seq <= last; seq++ {
+			if offset, ok := c.offsets[seq]; ok {
+				ledgers = append(ledgers, *c.readAt(offset))
+			}
+		}
+
+		return ledgers
+	}
+
+	if data := c.peek(); data != nil && data.Seq() > first {
+		log.Fatalf("Ledger %d is not available in the meta stream, next ledger is %d, ledgers must be requested in order", first, data.Seq())
+	}
+
+	c.skipTo(first)
+
+	for {
+		data := c.peek()
+
+		if data == nil || data.Seq() > last {
+			return ledgers
+		}
+
+		ledgers = append(ledgers, *c.take())
+	}
+}
+
+// LedgersForSeqs returns ledgers with given seqnums
+func (c *Client) LedgersForSeqs(seqs []int) []source.LedgerCloseData {
+	ledgers := []source.LedgerCloseData{}
+
+	for _, seq := range seqs {
+		ledgers = append(ledgers, c.LedgerRange(seq, seq)...)
+	}
+
+	return ledgers
+}
+
+// NextLedger returns the ledger following seq, nil if it has not been written yet
+func (c *Client) NextLedger(seq int) *source.LedgerCloseData {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.seekable {
+		if _, ok := c.offsets[seq+1]; !ok {
+			c.scan()
+		}
+
+		if offset, ok := c.offsets[seq+1]; ok {
+			return c.readAt(offset)
+		}
+
+		return nil
+	}
+
+	c.skipTo(seq + 1)
+
+	return c.take()
+}
+
+// skipTo drops pipe records preceding seq
+func (c *Client) skipTo(seq int) {
+	data := c.peek()
+
+	for data != nil && data.Seq() < seq {
+		c.take()
+		data = c.peek()
+	}
+}
@@ -0,0 +1,181 @@
+package metastream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+const fixturePassphrase = "Test SDF Network ; September 2015"
+
+// fixtureMeta returns LedgerCloseMeta of the ledger, the ledger 11 has one transaction
+func fixtureMeta(t *testing.T, seq uint32) (xdr.LedgerCloseMeta, xdr.Hash) {
+	var (
+		key  xdr.Uint256
+		hash xdr.Hash
+	)
+
+	meta := xdr.LedgerCloseMetaV0{
+		LedgerHeader: xdr.LedgerHeaderHistoryEntry{Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(seq)}},
+	}
+
+	if seq == 11 {
+		envelope := xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &key},
+					Fee:           100,
+					SeqNum:        1,
+				},
+			},
+		}
+
+		raw, err := network.HashTransactionInEnvelope(envelope, fixturePassphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash = xdr.Hash(raw)
+
+		meta.TxSet = xdr.TransactionSet{Txs: []xdr.TransactionEnvelope{envelope}}
+		meta.TxProcessing = []xdr.TransactionResultMeta{{
+			Result: xdr.TransactionResultPair{
+				TransactionHash: hash,
+				Result: xdr.TransactionResult{
+					FeeCharged: 100,
+					Result: xdr.TransactionResultResult{
+						Code:    xdr.TransactionResultCodeTxSuccess,
+						Results: &[]xdr.OperationResult{},
+					},
+				},
+			},
+			TxApplyProcessing: xdr.TransactionMeta{Operations: &[]xdr.OperationMeta{}},
+		}}
+	}
+
+	return xdr.LedgerCloseMeta{V: 0, V0: &meta}, hash
+}
+
+// writeRecords appends framed LedgerCloseMeta records of the ledgers, the way stellar-core does
+func writeRecords(t *testing.T, w *bytes.Buffer, seqs ...uint32) {
+	for _, seq := range seqs {
+		var body bytes.Buffer
+
+		meta, _ := fixtureMeta(t, seq)
+
+		if _, err := xdr.Marshal(&body, meta); err != nil {
+			t.Fatal(err)
+		}
+
+		header := make([]byte, 4)
+		binary.BigEndian.PutUint32(header, uint32(body.Len())|0x80000000)
+
+		w.Write(header)
+		w.Write(body.Bytes())
+	}
+}
+
+// recordFile writes the records into a temporary file, caller removes it
+func recordFile(t *testing.T, seqs ...uint32) string {
+	var buf bytes.Buffer
+
+	writeRecords(t, &buf, seqs...)
+
+	f, err := ioutil.TempFile("", "astrologer-meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	return f.Name()
+}
+
+func TestReplayFile(t *testing.T) {
+	path := recordFile(t, 10, 11, 12)
+	defer os.RemoveAll(path)
+
+	c := Open(path, fixturePassphrase)
+
+	if c.Sequential() {
+		t.Error("regular file is read as a pipe")
+	}
+
+	if first, last := c.FirstLedgerSeq(), c.LastLedgerSeq(); first != 10 || last != 12 {
+		t.Errorf("ledgers %d-%d, want 10-12", first, last)
+	}
+
+	ledgers := c.LedgerRange(11, 12)
+
+	if len(ledgers) != 2 || ledgers[0].Seq() != 11 || ledgers[1].Seq() != 12 {
+		t.Fatalf("LedgerRange(11, 12) returned %d ledgers", len(ledgers))
+	}
+
+	_, hash := fixtureMeta(t, 11)
+	txs := ledgers[0].Transactions
+
+	if len(txs) != 1 || txs[0].Hash != hex.EncodeToString(hash[:]) || txs[0].Index != 1 {
+		t.Errorf("ledger 11 transactions do not match the meta: %+v", txs)
+	}
+
+	if next := c.NextLedger(12); next != nil {
+		t.Errorf("NextLedger(12) returned ledger %d before it was written", next.Seq())
+	}
+
+	var buf bytes.Buffer
+	writeRecords(t, &buf, 13)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Write(buf.Bytes())
+	f.Close()
+
+	if next := c.NextLedger(12); next == nil || next.Seq() != 13 {
+		t.Error("NextLedger(12) did not return the appended ledger 13")
+	}
+}
+
+func TestReplayPipe(t *testing.T) {
+	var buf bytes.Buffer
+
+	writeRecords(t, &buf, 10, 11, 12, 13)
+
+	c := &Client{passphrase: fixturePassphrase, reader: bufio.NewReader(&buf)}
+
+	if !c.Sequential() {
+		t.Error("pipe is not read sequentially")
+	}
+
+	if first := c.FirstLedgerSeq(); first != 10 {
+		t.Errorf("FirstLedgerSeq() = %d, want 10", first)
+	}
+
+	ledgers := c.LedgerRange(11, 12)
+
+	if len(ledgers) != 2 || ledgers[0].Seq() != 11 || ledgers[1].Seq() != 12 {
+		t.Fatalf("LedgerRange(11, 12) returned %d ledgers", len(ledgers))
+	}
+
+	if next := c.NextLedger(12); next == nil || next.Seq() != 13 {
+		t.Error("NextLedger(12) did not return ledger 13")
+	}
+
+	if next := c.NextLedger(13); next != nil {
+		t.Errorf("NextLedger(13) returned ledger %d after the end of stream", next.Seq())
+	}
+}
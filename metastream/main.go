@@ -0,0 +1,176 @@
+package metastream
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/astroband/astrologer/source"
+	"github.com/astroband/astrologer/util"
+	"github.com/stellar/go/xdr"
+)
+
+// Client is a source.LedgerSource implementation reading framed LedgerCloseMeta records
+// written by stellar-core metadata output stream.
+//
+// Regular files are indexed on open and may be read in any order, files being appended to
+// are indexed further on demand. Named pipes and stdin are read sequentially, so ledgers
+// must be requested in ascending order (use --concurrency=1 for export).
+type Client struct {
+	passphrase string
+	file       *os.File
+	seekable   bool
+
+	// Seekable files: record offsets by ledger seq and position right after the last complete record
+	offsets map[int]int64
+	first   int
+	last    int
+	end     int64
+
+	// Pipes: buffered reader and the record read ahead
+	reader  *bufio.Reader
+	pending *source.LedgerCloseData
+
+	mutex sync.Mutex
+}
+
+// Open returns the Client reading meta stream from the given path, "-" means stdin
+func Open(path string, passphrase string) *Client {
+	var err error
+
+	c := &Client{passphrase: passphrase, file: os.Stdin}
+
+	if path != "-" {
+		c.file, err = os.Open(path)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	info, err := c.file.Stat()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c.seekable = info.Mode().IsRegular()
+
+	if c.seekable {
+		c.offsets = make(map[int]int64)
+		c.scan()
+	} else {
+		c.reader = bufio.NewReader(c.file)
+	}
+
+	return c
+}
+
+// decode converts raw record into LedgerCloseData
+func (c *Client) decode(record []byte) *source.LedgerCloseData {
+	var meta xdr.LedgerCloseMeta
+
+	if err := xdr.SafeUnmarshal(record, &meta); err != nil {
+		log.Fatalf("Failed to decode LedgerCloseMeta: %v", err)
+	}
+
+	data, err := source.NewLedgerCloseDataFromMeta(meta, c.passphrase)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return data
+}
+
+// recordSeq reads ledger seq from the header of LedgerCloseMeta record without decoding transactions
+func recordSeq(record []byte) int {
+	var header xdr.LedgerHeaderHistoryEntry
+
+	// The record starts with LedgerCloseMeta version followed by the ledger header
+	if len(record) < 4 {
+		log.Fatal("Malformed LedgerCloseMeta record")
+	}
+
+	if _, err := xdr.Unmarshal(bytes.NewReader(record[4:]), &header); err != nil {
+		log.Fatalf("Failed to decode LedgerCloseMeta header: %v", err)
+	}
+
+	return int(header.Header.LedgerSeq)
+}
+
+// scan indexes complete records appended to the file since the previous scan, must be called under lock
+func (c *Client) scan() {
+	r := bufio.NewReader(io.NewSectionReader(c.file, c.end, 1<<62))
+
+	for {
+		record, err := util.ReadXdrRecord(r)
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		seq := recordSeq(record)
+
+		if c.first == 0 {
+			c.first = seq
+		}
+
+		c.last = seq
+		c.offsets[seq] = c.end
+		c.end += int64(len(record)) + 4
+	}
+}
+
+// readAt reads the record at given offset of the seekable file
+func (c *Client) readAt(offset int64) *source.LedgerCloseData {
+	record, err := util.ReadXdrRecord(io.NewSectionReader(c.file, offset, c.end-offset))
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return c.decode(record)
+}
+
+// peek returns the next record of the pipe without consuming it, nil if the stream has ended
+func (c *Client) peek() *source.LedgerCloseData {
+	if c.pending != nil {
+		return c.pending
+	}
+
+	record, err := util.ReadXdrRecord(c.reader)
+
+	if err == io.EOF {
+		return nil
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c.pending = c.decode(record)
+
+	if c.first == 0 {
+		c.first = c.pending.Seq()
+	}
+
+	c.last = c.pending.Seq()
+
+	return c.pending
+}
+
+// take consumes the next record of the pipe
+func (c *Client) take() *source.LedgerCloseData {
+	data := c.peek()
+	c.pending = nil
+
+	return data
+}
@@ -0,0 +1,62 @@
+package sink
+
+import (
+	"bytes"
+	"log"
+
+	"github.com/astroband/astrologer/es"
+)
+
+// Elastic is a sink sending documents to ElasticSearch using bulk API
+type Elastic struct {
+	ES         es.Adapter
+	Action     es.BulkAction
+	RetryCount int
+	Partition  es.Partitioning
+
+	// cursorDropped is the number of documents dropped when the cursor was saved last time
+	cursorDropped int
+}
+
+// Write sends documents to ES in a single bulk
+func (s *Elastic) Write(docs []es.Indexable) {
+	var b bytes.Buffer
+
+	for _, doc := range docs {
+		alias := s.ES.Indices().Name(doc.IndexName())
+		es.SerializeForBulk(doc, s.Partition.IndexFor(doc, alias), s.Action, &b)
+	}
+
+	s.ES.IndexWithRetries(&b, s.RetryCount)
+}
+
+// Close prints the summary of documents sent to ES
+func (s *Elastic) Close() {
+	stats := s.ES.BulkStats()
+
+	for name, count := range stats.Dropped {
+		log.Printf("Dropped %d documents from %s index", count, name)
+	}
+
+	log.Printf("Indexed %d documents, skipped %d existing, dropped %d", stats.Indexed, stats.Skipped, stats.TotalDropped())
+}
+
+// LoadCursor returns the cursor stored in the state index
+func (s *Elastic) LoadCursor(name string) int {
+	return s.ES.LoadCursor(name)
+}
+
+// SaveCursor stores the cursor in the state index, it refuses to advance the cursor over ledgers
+// documents of which were dropped, so the next run starts from the ledger following the last complete one
+func (s *Elastic) SaveCursor(name string, seq int) {
+	dropped := s.ES.BulkStats().TotalDropped()
+
+	if dropped > s.cursorDropped {
+		log.Fatalf(
+			"%d documents were dropped up to ledger %d, cursor %s is not advanced",
+			dropped-s.cursorDropped, seq, name,
+		)
+	}
+
+	s.ES.SaveCursor(name, seq)
+}
@@ -0,0 +1,21 @@
+package sink
+
+import (
+	"github.com/astroband/astrologer/es"
+)
+
+// Sink represents the destination of documents produced from ledgers
+type Sink interface {
+	// Write persists a batch of documents, documents of every ledger are preceded by its header
+	Write(docs []es.Indexable)
+
+	// Close finishes writing and reports the summary
+	Close()
+
+	// LoadCursor returns the ledger seq persisted for the cursor name, zero if cursor does not exist
+	LoadCursor(name string) int
+
+	// SaveCursor persists the last ledger written by the named process, it fails if documents written
+	// since the previous cursor were not persisted
+	SaveCursor(name string, seq int)
+}
@@ -0,0 +1,202 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astroband/astrologer/es"
+)
+
+// NDJSON is a sink writing documents into newline delimited JSON files, one file per index.
+// If Rotate is set, files are gzipped and split by ledger ranges of the given size.
+type NDJSON struct {
+	Dir    string
+	Rotate int
+
+	locks   map[string]*sync.Mutex
+	written map[es.IndexName]int
+	mutex   sync.Mutex
+}
+
+// NewNDJSON creates NDJSON sink writing into the given directory
+func NewNDJSON(dir string, rotate int) *NDJSON {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	return &NDJSON{
+		Dir:     dir,
+		Rotate:  rotate,
+		locks:   make(map[string]*sync.Mutex),
+		written: make(map[es.IndexName]int),
+	}
+}
+
+// Write appends documents to files of their indices
+func (s *NDJSON) Write(docs []es.Indexable) {
+	var seq int
+
+	files := make(map[string]*bytes.Buffer)
+	written := make(map[es.IndexName]int)
+
+	for _, doc := range docs {
+		if h, ok := doc.(*es.LedgerHeader); ok {
+			seq = h.Seq
+		}
+
+		data, err := json.Marshal(doc)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		path := s.path(doc.IndexName(), seq)
+
+		if files[path] == nil {
+			files[path] = new(bytes.Buffer)
+		}
+
+		files[path].Write(data)
+		files[path].WriteByte('\n')
+
+		written[doc.IndexName()]++
+	}
+
+	for path, b := range files {
+		s.append(path, b.Bytes())
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name, count := range written {
+		s.written[name] += count
+	}
+}
+
+// Close prints the summary of written documents
+func (s *NDJSON) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name, count := range s.written {
+		log.Printf("Written %d documents of %s index", count, name)
+	}
+}
+
+// LoadCursor returns the cursor stored in the sidecar file next to the documents
+func (s *NDJSON) LoadCursor(name string) int {
+	var cursor es.Cursor
+
+	data, err := ioutil.ReadFile(s.cursorPath(name))
+
+	if os.IsNotExist(err) {
+		return 0
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		log.Fatalf("Error parsing cursor %s: %s", name, err)
+	}
+
+	return cursor.LedgerSeq
+}
+
+// SaveCursor replaces the sidecar cursor file, the file is renamed into place so it is never left half written
+func (s *NDJSON) SaveCursor(name string, seq int) {
+	path := s.cursorPath(name)
+	cursor := es.Cursor{Name: name, LedgerSeq: seq, UpdatedAt: time.Now().UTC()}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// cursorPath returns the sidecar cursor file name, eg. ingest.cursor.json
+func (s *NDJSON) cursorPath(name string) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%s.cursor.json", name))
+}
+
+// path returns file name for the index and ledger, eg. op.ndjson or op/op-000000000000-000000009999.ndjson.gz
+func (s *NDJSON) path(name es.IndexName, seq int) string {
+	if s.Rotate == 0 {
+		return filepath.Join(s.Dir, fmt.Sprintf("%s.ndjson", name))
+	}
+
+	from := seq / s.Rotate * s.Rotate
+
+	return filepath.Join(
+		s.Dir,
+		string(name),
+		fmt.Sprintf("%s-%012d-%012d.ndjson.gz", name, from, from+s.Rotate-1),
+	)
+}
+
+func (s *NDJSON) lock(path string) *sync.Mutex {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.locks[path] == nil {
+		s.locks[path] = new(sync.Mutex)
+	}
+
+	return s.locks[path]
+}
+
+// append writes data to the end of the file, gzipped files get a new gzip member which is valid for gzip readers
+func (s *NDJSON) append(path string, data []byte) {
+	lock := s.lock(path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer f.Close()
+
+	if !strings.HasSuffix(path, ".gz") {
+		if _, err := f.Write(data); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	w := gzip.NewWriter(f)
+
+	if _, err := w.Write(data); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
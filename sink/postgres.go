@@ -0,0 +1,205 @@
+package sink
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/astroband/astrologer/es"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Postgres is a sink writing documents into normalized Postgres tables
+type Postgres struct {
+	rawClient *sqlx.DB
+
+	// Overwrite updates rows having the same paging token, otherwise existing rows are kept
+	Overwrite bool
+
+	written map[string]int
+	skipped map[es.IndexName]int
+	mutex   sync.Mutex
+}
+
+// NewPostgres connects to the database documents are written to
+func NewPostgres(databaseURL *url.URL, overwrite bool) *Postgres {
+	db, err := sqlx.Connect("postgres", (*databaseURL).String())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return &Postgres{
+		rawClient: db,
+		Overwrite: overwrite,
+		written:   make(map[string]int),
+		skipped:   make(map[es.IndexName]int),
+	}
+}
+
+// CreateTables creates Astrologer tables, dropping existing ones if force is set
+func (s *Postgres) CreateTables(force bool) {
+	for name, def := range tableDefinitions() {
+		if force {
+			_, err := s.rawClient.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", name))
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		_, err := s.rawClient.Exec(def)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("%s table created!", name)
+	}
+}
+
+// Write copies documents into the tables in a single transaction, documents having no table are counted
+// as skipped
+func (s *Postgres) Write(docs []es.Indexable) {
+	rows := make(map[*table][][]interface{})
+	skipped := make(map[es.IndexName]int)
+
+	for _, doc := range docs {
+		t, row := tableRow(doc)
+
+		if t == nil {
+			skipped[doc.IndexName()]++
+			continue
+		}
+
+		rows[t] = append(rows[t], row)
+	}
+
+	tx, err := s.rawClient.Beginx()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for t, r := range rows {
+		s.copy(tx, t, r)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s.mutex.Lock()
+	for t, r := range rows {
+		s.written[t.name] += len(r)
+	}
+	for name, count := range skipped {
+		s.skipped[name] += count
+	}
+	s.mutex.Unlock()
+}
+
+// Close prints the summary of rows written
+func (s *Postgres) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for name, count := range s.written {
+		log.Printf("Written %d rows to %s table", count, name)
+	}
+
+	for name, count := range s.skipped {
+		log.Printf("Skipped %d %s documents, there is no table for them", count, name)
+	}
+
+	s.rawClient.Close()
+}
+
+// LoadCursor returns the cursor stored in the cursors table
+func (s *Postgres) LoadCursor(name string) int {
+	var seq int
+
+	err := s.rawClient.Get(&seq, "SELECT ledger_seq FROM cursors WHERE name = $1", name)
+
+	if err == sql.ErrNoRows {
+		return 0
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return seq
+}
+
+// SaveCursor stores the cursor in the cursors table
+func (s *Postgres) SaveCursor(name string, seq int) {
+	_, err := s.rawClient.Exec(
+		`INSERT INTO cursors (name, ledger_seq, updated_at) VALUES ($1, $2, now())
+		ON CONFLICT (name) DO UPDATE SET ledger_seq = EXCLUDED.ledger_seq, updated_at = EXCLUDED.updated_at`,
+		name, seq,
+	)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// copy loads rows into a temporary table using COPY and upserts them into the target table. Rows having
+// the same paging token within the batch are upserted once, the last one wins.
+func (s *Postgres) copy(tx *sqlx.Tx, t *table, rows [][]interface{}) {
+	tmp := "tmp_" + t.name
+
+	_, err := tx.Exec(fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP", tmp, t.name))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn(tmp, t.columns...))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, row := range rows {
+		_, err = stmt.Exec(row...)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = stmt.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = tx.Exec(s.upsertQuery(t, tmp))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (s *Postgres) upsertQuery(t *table, tmp string) string {
+	columns := strings.Join(t.columns, ", ")
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) SELECT DISTINCT ON (paging_token) %s FROM %s ORDER BY paging_token, ctid DESC "+
+			"ON CONFLICT (paging_token) DO ",
+		t.name, columns, columns, tmp,
+	)
+
+	if !s.Overwrite {
+		return query + "NOTHING"
+	}
+
+	updates := make([]string, 0, len(t.columns)-1)
+	for _, c := range t.columns[1:] {
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", c, c))
+	}
+
+	return query + "UPDATE SET " + strings.Join(updates, ", ")
+}
@@ -0,0 +1,148 @@
+package sink
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/astroband/astrologer/es"
+)
+
+// table describes Postgres table columns documents are copied into
+type table struct {
+	name    string
+	columns []string
+}
+
+var (
+	ledgersTable = &table{"ledgers", []string{
+		"paging_token", "seq", "hash", "prev_hash", "bucket_list_hash", "close_time", "version",
+		"total_coins", "fee_pool", "inflation_seq", "id_pool", "base_fee", "base_reserve", "max_tx_set_size",
+	}}
+
+	transactionsTable = &table{"transactions", []string{
+		"paging_token", "id", "idx", "seq", "max_fee", "fee_charged", "fee_account_id", "operation_count",
+		"close_time", "successful", "result_code", "source_account_id", "memo_type", "memo_value",
+		"min_time", "max_time",
+	}}
+
+	operationsTable = &table{"operations", []string{
+		"paging_token", "tx_id", "tx_idx", "idx", "seq", "close_time", "successful", "result_code",
+		"inner_result_code", "type", "tx_source_account_id", "source_account_id", "source_asset_code",
+		"source_asset_issuer", "source_amount", "destination_account_id", "destination_asset_code",
+		"destination_asset_issuer", "destination_amount", "offer_id", "offer_price", "details",
+	}}
+
+	balancesTable = &table{"balances", []string{
+		"paging_token", "account_id", "asset_code", "asset_issuer", "value", "prev_value", "diff", "positive",
+		"removed", "buying_liabilities", "selling_liabilities", "minimum_reserve", "available", "source", "created_at",
+	}}
+
+	tradesTable = &table{"trades", []string{
+		"paging_token", "sold", "bought", "asset_sold_code", "asset_sold_issuer", "asset_bought_code",
+		"asset_bought_issuer", "offer_id", "seller_id", "buyer_id", "price", "ledger_close_time",
+	}}
+
+	signersTable = &table{"signers", []string{
+		"paging_token", "account_id", "signer", "type", "weight", "prev_weight", "action", "seq", "tx_idx", "idx",
+		"ledger_close_time",
+	}}
+)
+
+// tableRow returns the table and column values for the document, nil table if document has no table
+func tableRow(doc es.Indexable) (*table, []interface{}) {
+	switch d := doc.(type) {
+	case *es.LedgerHeader:
+		return ledgersTable, []interface{}{
+			d.PagingToken.String(), d.Seq, d.Hash, d.PrevHash, d.BucketListHash, d.CloseTime, d.Version,
+			d.TotalCoins, d.FeePool, d.InflationSeq, d.IDPool, d.BaseFee, d.BaseReserve, d.MaxTxSetSize,
+		}
+	case *es.Transaction:
+		row := []interface{}{
+			d.PagingToken.String(), d.ID, d.Index, d.Seq, d.MaxFee, d.FeeCharged, nullString(d.FeeAccountID),
+			d.OperationCount, d.CloseTime, d.Successful, d.ResultCode, d.SourceAccountID, nil, nil, nil, nil,
+		}
+
+		if d.Memo != nil {
+			row[12], row[13] = d.Memo.Type, d.Memo.Value
+		}
+
+		if d.TimeBounds != nil {
+			row[14], row[15] = d.TimeBounds.MinTime, d.TimeBounds.MaxTime
+		}
+
+		return transactionsTable, row
+	case *es.Operation:
+		details, err := json.Marshal(d)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return operationsTable, []interface{}{
+			d.PagingToken.String(), d.TxID, d.TxIndex, d.Index, d.Seq, d.CloseTime, d.Successful, d.ResultCode,
+			d.InnerResultCode, d.Type, d.TxSourceAccountID, nullString(d.SourceAccountID), assetCode(d.SourceAsset),
+			assetIssuer(d.SourceAsset), nullString(d.SourceAmount), nullString(d.DestinationAccountID),
+			assetCode(d.DestinationAsset), assetIssuer(d.DestinationAsset), nullString(d.DestinationAmount),
+			nullInt(d.OfferID), nullFloat(d.OfferPrice), string(details),
+		}
+	case *es.Balance:
+		return balancesTable, []interface{}{
+			d.PagingToken.String(), d.AccountID, d.Asset.Code, nullString(d.Asset.Issuer), d.Value, d.PrevValue,
+			d.Diff, d.Positive, d.Removed, d.BuyingLiabilities, d.SellingLiabilities, d.MinimumReserve, d.Available,
+			string(d.Source), d.CreatedAt,
+		}
+	case *es.Trade:
+		return tradesTable, []interface{}{
+			d.PagingToken.String(), d.Sold, d.Bought, d.AssetSold.Code, nullString(d.AssetSold.Issuer),
+			d.AssetBought.Code, nullString(d.AssetBought.Issuer), d.OfferID, d.SellerID, d.BuyerID, d.Price,
+			d.LedgerCloseTime,
+		}
+	case *es.SignerHistory:
+		return signersTable, []interface{}{
+			d.PagingToken.String(), d.AccountID, d.Signer, d.Type, d.Weight, d.PrevWeight, string(d.Action), d.Seq,
+			d.TxIndex, d.Index, d.LedgerCloseTime,
+		}
+	}
+
+	return nil, nil
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func nullInt(i int) interface{} {
+	if i == 0 {
+		return nil
+	}
+
+	return i
+}
+
+func nullFloat(f float64) interface{} {
+	if f == 0 {
+		return nil
+	}
+
+	return f
+}
+
+func assetCode(a *es.Asset) interface{} {
+	if a == nil {
+		return nil
+	}
+
+	return a.Code
+}
+
+func assetIssuer(a *es.Asset) interface{} {
+	if a == nil {
+		return nil
+	}
+
+	return nullString(a.Issuer)
+}
@@ -0,0 +1,147 @@
+package sink
+
+// tableDefinitions returns Postgres schema for Astrologer tables, every document table has paging_token
+// primary key
+func tableDefinitions() map[string]string {
+	m := make(map[string]string)
+
+	m["cursors"] = `
+		CREATE TABLE IF NOT EXISTS cursors (
+			name text PRIMARY KEY,
+			ledger_seq bigint NOT NULL,
+			updated_at timestamptz NOT NULL
+		);
+	`
+
+	m["ledgers"] = `
+		CREATE TABLE IF NOT EXISTS ledgers (
+			paging_token text PRIMARY KEY,
+			seq bigint NOT NULL,
+			hash text NOT NULL,
+			prev_hash text NOT NULL,
+			bucket_list_hash text NOT NULL,
+			close_time timestamptz NOT NULL,
+			version integer NOT NULL,
+			total_coins bigint NOT NULL,
+			fee_pool bigint NOT NULL,
+			inflation_seq bigint NOT NULL,
+			id_pool bigint NOT NULL,
+			base_fee integer NOT NULL,
+			base_reserve integer NOT NULL,
+			max_tx_set_size integer NOT NULL
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS ledgers_seq_idx ON ledgers (seq);
+	`
+
+	m["transactions"] = `
+		CREATE TABLE IF NOT EXISTS transactions (
+			paging_token text PRIMARY KEY,
+			id text NOT NULL,
+			idx integer NOT NULL,
+			seq bigint NOT NULL,
+			max_fee bigint NOT NULL,
+			fee_charged bigint NOT NULL,
+			fee_account_id text,
+			operation_count integer NOT NULL,
+			close_time timestamptz NOT NULL,
+			successful boolean NOT NULL,
+			result_code integer NOT NULL,
+			source_account_id text NOT NULL,
+			memo_type smallint,
+			memo_value text,
+			min_time bigint,
+			max_time bigint
+		);
+		CREATE INDEX IF NOT EXISTS transactions_id_idx ON transactions (id);
+		CREATE INDEX IF NOT EXISTS transactions_source_account_id_idx ON transactions (source_account_id);
+	`
+
+	m["operations"] = `
+		CREATE TABLE IF NOT EXISTS operations (
+			paging_token text PRIMARY KEY,
+			tx_id text NOT NULL,
+			tx_idx integer NOT NULL,
+			idx integer NOT NULL,
+			seq bigint NOT NULL,
+			close_time timestamptz NOT NULL,
+			successful boolean NOT NULL,
+			result_code integer NOT NULL,
+			inner_result_code integer NOT NULL,
+			type text NOT NULL,
+			tx_source_account_id text NOT NULL,
+			source_account_id text,
+			source_asset_code text,
+			source_asset_issuer text,
+			source_amount numeric,
+			destination_account_id text,
+			destination_asset_code text,
+			destination_asset_issuer text,
+			destination_amount numeric,
+			offer_id bigint,
+			offer_price double precision,
+			details jsonb NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS operations_tx_id_idx ON operations (tx_id);
+		CREATE INDEX IF NOT EXISTS operations_source_account_id_idx ON operations (source_account_id);
+		CREATE INDEX IF NOT EXISTS operations_destination_account_id_idx ON operations (destination_account_id);
+	`
+
+	m["balances"] = `
+		CREATE TABLE IF NOT EXISTS balances (
+			paging_token text PRIMARY KEY,
+			account_id text NOT NULL,
+			asset_code text NOT NULL,
+			asset_issuer text,
+			value numeric NOT NULL,
+			prev_value numeric NOT NULL,
+			diff numeric NOT NULL,
+			positive boolean NOT NULL,
+			removed boolean NOT NULL,
+			buying_liabilities numeric NOT NULL,
+			selling_liabilities numeric NOT NULL,
+			minimum_reserve numeric NOT NULL,
+			available numeric NOT NULL,
+			source text NOT NULL,
+			created_at timestamptz NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS balances_account_id_idx ON balances (account_id, asset_code, asset_issuer);
+	`
+
+	m["trades"] = `
+		CREATE TABLE IF NOT EXISTS trades (
+			paging_token text PRIMARY KEY,
+			sold numeric NOT NULL,
+			bought numeric NOT NULL,
+			asset_sold_code text NOT NULL,
+			asset_sold_issuer text,
+			asset_bought_code text NOT NULL,
+			asset_bought_issuer text,
+			offer_id bigint NOT NULL,
+			seller_id text NOT NULL,
+			buyer_id text NOT NULL,
+			price numeric NOT NULL,
+			ledger_close_time timestamptz NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS trades_seller_id_idx ON trades (seller_id);
+		CREATE INDEX IF NOT EXISTS trades_buyer_id_idx ON trades (buyer_id);
+	`
+
+	m["signers"] = `
+		CREATE TABLE IF NOT EXISTS signers (
+			paging_token text PRIMARY KEY,
+			account_id text NOT NULL,
+			signer text NOT NULL,
+			type smallint NOT NULL,
+			weight integer NOT NULL,
+			prev_weight integer NOT NULL,
+			action text NOT NULL,
+			seq bigint NOT NULL,
+			tx_idx integer NOT NULL,
+			idx integer NOT NULL,
+			ledger_close_time timestamptz NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS signers_account_id_idx ON signers (account_id);
+	`
+
+	return m
+}
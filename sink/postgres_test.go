@@ -0,0 +1,18 @@
+package sink
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpsertQueryDeduplicatesBatch(t *testing.T) {
+	tbl := &table{"trades", []string{"paging_token", "sold"}}
+
+	for _, overwrite := range []bool{true, false} {
+		query := (&Postgres{Overwrite: overwrite}).upsertQuery(tbl, "tmp_trades")
+
+		if !strings.Contains(query, "SELECT DISTINCT ON (paging_token) paging_token, sold FROM tmp_trades") {
+			t.Errorf("rows are not deduplicated by paging token: %s", query)
+		}
+	}
+}
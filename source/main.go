@@ -0,0 +1,40 @@
+package source
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/stellar/go/xdr"
+)
+
+// LedgerSource represents a provider of closed ledgers, like stellar-core database or history archive
+type LedgerSource interface {
+	FirstLedgerSeq() int
+	LastLedgerSeq() int
+	LedgerCount(first int, last int) int
+	LedgerSeqsInRange(first int, last int) []int
+	LedgerRange(first int, last int) []LedgerCloseData
+	LedgersForSeqs(seqs []int) []LedgerCloseData
+	NextLedger(seq int) *LedgerCloseData
+}
+
+// LedgerCloseData represents closed ledger along with its transactions
+type LedgerCloseData struct {
+	Header       xdr.LedgerHeaderHistoryEntry
+	Transactions []Transaction
+}
+
+// Seq returns ledger sequence number
+func (l *LedgerCloseData) Seq() int {
+	return int(l.Header.Header.LedgerSeq)
+}
+
+// Hash returns hex encoded ledger hash
+func (l *LedgerCloseData) Hash() string {
+	return hex.EncodeToString(l.Header.Hash[:])
+}
+
+// CloseTime returns ledger close time
+func (l *LedgerCloseData) CloseTime() time.Time {
+	return time.Unix(int64(l.Header.Header.ScpValue.CloseTime), 0)
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+	"github.com/stellar/go/xdr"
+)
+
+// Transaction represents applied transaction with its result, meta and fee changes
+type Transaction struct {
+	Hash       string
+	Index      int
+	Envelope   xdr.TransactionEnvelope
+	Result     xdr.TransactionResultPair
+	Meta       xdr.TransactionMeta
+	FeeChanges xdr.LedgerEntryChanges
+}
+
+// utf8Scrub replaces invalid UTF-8 sequences, copy paste from Horizon
+func utf8Scrub(in string) string {
+
+	// First check validity using the stdlib, returning if the string is already
+	// valid
+	if utf8.ValidString(in) {
+		return in
+	}
+
+	left := []byte(in)
+	var result bytes.Buffer
+
+	for len(left) > 0 {
+		r, n := utf8.DecodeRune(left)
+
+		_, err := result.WriteRune(r)
+		if err != nil {
+			panic(err)
+		}
+
+		left = left[n:]
+	}
+
+	return result.String()
+}
+
+// MemoValue Returns clean memo value, this is copy paste from horizon internal package
+func (tx *Transaction) MemoValue() null.String {
+	var (
+		value string
+		valid bool
+		memo  = tx.Envelope.Memo()
+	)
+
+	switch memo.Type {
+	case xdr.MemoTypeMemoNone:
+		value, valid = "", false
+	case xdr.MemoTypeMemoText:
+		scrubbed := utf8Scrub(memo.MustText())
+		notnull := strings.Join(strings.Split(scrubbed, "\x00"), "")
+		value, valid = notnull, true
+	case xdr.MemoTypeMemoId:
+		value, valid = fmt.Sprintf("%d", memo.MustId()), true
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		value, valid =
+			base64.StdEncoding.EncodeToString(hash[:]),
+			true
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		value, valid =
+			base64.StdEncoding.EncodeToString(hash[:]),
+			true
+	default:
+		panic(fmt.Errorf("invalid memo type: %v", memo.Type))
+	}
+
+	return null.NewString(value, valid)
+}
+
+// Operations returns operations array
+func (tx *Transaction) Operations() (error, []xdr.Operation) {
+	switch tx.Envelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		return nil, tx.Envelope.V0.Tx.Operations
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return nil, tx.Envelope.V1.Tx.Operations
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return nil, tx.Envelope.FeeBump.Tx.InnerTx.V1.Tx.Operations
+	default:
+		return fmt.Errorf("Unknown tx envelope type %s", tx.Envelope.Type), make([]xdr.Operation, 0)
+	}
+}
+
+// ResultFor returns result for operation index, results of fee bump transactions are taken from the inner transaction
+func (tx *Transaction) ResultFor(index int) (result *xdr.OperationResult) {
+	results := tx.Result.Result.Result.Results
+
+	if pair, ok := tx.Result.Result.Result.GetInnerResultPair(); ok {
+		results = pair.Result.Result.Results
+	}
+
+	if results != nil {
+		result = &(*results)[index]
+	}
+
+	return result
+}
+
+// MetasFor returns meta for operation index, nil if meta is not available
+func (tx *Transaction) MetasFor(index int) (result *xdr.OperationMeta) {
+	if v1, ok := tx.Meta.GetV1(); ok {
+		ops := v1.Operations
+
+		if index >= len(ops) {
+			return nil
+		}
+
+		return &ops[index]
+	}
+
+	if v2, ok := tx.Meta.GetV2(); ok {
+		ops := v2.Operations
+
+		if index >= len(ops) {
+			return nil
+		}
+
+		return &ops[index]
+	}
+
+	ops, ok := tx.Meta.GetOperations()
+	if !ok || index >= len(ops) {
+		return nil
+	}
+
+	return &ops[index]
+}
+
+// TxChangesBefore returns changes applied by the transaction before its operations, like sequence number bump
+func (tx *Transaction) TxChangesBefore() xdr.LedgerEntryChanges {
+	if v1, ok := tx.Meta.GetV1(); ok {
+		return v1.TxChanges
+	}
+
+	if v2, ok := tx.Meta.GetV2(); ok {
+		return v2.TxChangesBefore
+	}
+
+	return nil
+}
+
+// TxChangesAfter returns changes applied by the transaction after its operations
+func (tx *Transaction) TxChangesAfter() xdr.LedgerEntryChanges {
+	if v2, ok := tx.Meta.GetV2(); ok {
+		return v2.TxChangesAfter
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+func TestResultForFeeBumpTakesInnerResults(t *testing.T) {
+	outer := []xdr.OperationResult{{Code: xdr.OperationResultCodeOpBadAuth}}
+	inner := []xdr.OperationResult{{Code: xdr.OperationResultCodeOpInner}}
+
+	tx := Transaction{
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:    xdr.TransactionResultCodeTxFeeBumpInnerSuccess,
+					Results: &outer,
+					InnerResultPair: &xdr.InnerTransactionResultPair{
+						Result: xdr.InnerTransactionResult{
+							Result: xdr.InnerTransactionResultResult{
+								Code:    xdr.TransactionResultCodeTxSuccess,
+								Results: &inner,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if result := tx.ResultFor(0); result == nil || result.Code != xdr.OperationResultCodeOpInner {
+		t.Errorf("expected inner operation result, got %v", result)
+	}
+}
+
+func TestMetasForV2(t *testing.T) {
+	ops := []xdr.OperationMeta{{}, {}}
+
+	tx := Transaction{
+		Meta: xdr.TransactionMeta{V: 2, V2: &xdr.TransactionMetaV2{Operations: ops}},
+	}
+
+	if meta := tx.MetasFor(1); meta != &ops[1] {
+		t.Errorf("expected meta of the second operation, got %v", meta)
+	}
+
+	if meta := tx.MetasFor(2); meta != nil {
+		t.Errorf("expected no meta past the last operation, got %v", meta)
+	}
+}
@@ -0,0 +1,66 @@
+package source
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+// EnvelopesByHash returns transaction envelopes of the tx set indexed by their hashes
+func EnvelopesByHash(envelopes []xdr.TransactionEnvelope, passphrase string) (map[xdr.Hash]xdr.TransactionEnvelope, error) {
+	result := make(map[xdr.Hash]xdr.TransactionEnvelope, len(envelopes))
+
+	for _, envelope := range envelopes {
+		hash, err := network.HashTransactionInEnvelope(envelope, passphrase)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[xdr.Hash(hash)] = envelope
+	}
+
+	return result, nil
+}
+
+// NewLedgerCloseDataFromMeta converts LedgerCloseMeta emitted by stellar-core into LedgerCloseData
+func NewLedgerCloseDataFromMeta(meta xdr.LedgerCloseMeta, passphrase string) (*LedgerCloseData, error) {
+	v0, ok := meta.GetV0()
+
+	if !ok {
+		return nil, fmt.Errorf("Unsupported LedgerCloseMeta version %d", meta.V)
+	}
+
+	envelopes, err := EnvelopesByHash(v0.TxSet.Txs, passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := &LedgerCloseData{Header: v0.LedgerHeader}
+
+	// TxProcessing is stored in the apply order, which is the order of txhistory rows in core
+	for n, tx := range v0.TxProcessing {
+		envelope, ok := envelopes[tx.Result.TransactionHash]
+
+		if !ok {
+			return nil, fmt.Errorf(
+				"Transaction %x of ledger %d not found, check network passphrase",
+				tx.Result.TransactionHash, data.Seq(),
+			)
+		}
+
+		data.Transactions = append(data.Transactions, Transaction{
+			Hash:       hex.EncodeToString(tx.Result.TransactionHash[:]),
+			Index:      n + 1,
+			Envelope:   envelope,
+			Result:     tx.Result,
+			Meta:       tx.TxApplyProcessing,
+			FeeChanges: tx.FeeProcessing,
+		})
+	}
+
+	return data, nil
+}
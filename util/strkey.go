@@ -2,10 +2,19 @@ package util
 
 import (
 	"encoding"
+	"encoding/base32"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/stellar/go/crc16"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/xdr"
 )
 
+// versionByteMuxedAccount is the strkey version byte of M-addresses, strkey package of the stellar/go
+// version used does not know it yet
+const versionByteMuxedAccount byte = 12 << 3
+
 func EncodeEd25519(a encoding.BinaryMarshaler) (string, error) {
 	accountIdBin, err := a.MarshalBinary()
 
@@ -16,6 +25,7 @@ func EncodeEd25519(a encoding.BinaryMarshaler) (string, error) {
 	return strkey.Encode(strkey.VersionByteAccountID, accountIdBin)
 }
 
+// EncodeMuxedAccount returns G-address of the account, id of muxed account is dropped
 func EncodeMuxedAccount(a xdr.MuxedAccount) (string, error) {
 	var (
 		accountIdBin []byte
@@ -34,3 +44,27 @@ func EncodeMuxedAccount(a xdr.MuxedAccount) (string, error) {
 
 	return strkey.Encode(strkey.VersionByteAccountID, accountIdBin)
 }
+
+// EncodeMuxedAddress returns M-address and id of muxed account, both are empty for plain ed25519 accounts.
+// M-address payload is ed25519 key followed by big-endian id as defined by SEP-23.
+func EncodeMuxedAddress(a xdr.MuxedAccount) (address string, id string, err error) {
+	if a.Type != xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
+		return "", "", nil
+	}
+
+	m := a.Med25519
+	payload := make([]byte, 40)
+
+	copy(payload, m.Ed25519[:])
+	binary.BigEndian.PutUint64(payload[32:], uint64(m.Id))
+
+	return encodeMuxedStrkey(payload), strconv.FormatUint(uint64(m.Id), 10), nil
+}
+
+// encodeMuxedStrkey encodes payload the way strkey.Encode does: version byte, payload and CRC16 checksum
+func encodeMuxedStrkey(payload []byte) string {
+	raw := append([]byte{versionByteMuxedAccount}, payload...)
+	raw = append(raw, crc16.Checksum(raw)...)
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw)
+}
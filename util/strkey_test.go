@@ -0,0 +1,54 @@
+package util
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+func TestEncodeMuxedAddress(t *testing.T) {
+	raw, err := strkey.Decode(strkey.VersionByteAccountID, "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var key xdr.Uint256
+	copy(key[:], raw)
+
+	// SEP-23 test vectors
+	cases := []struct {
+		id      xdr.Uint64
+		address string
+	}{
+		{0, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ"},
+		{9223372036854775808, "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"},
+	}
+
+	for _, c := range cases {
+		muxed := xdr.MuxedAccount{
+			Type:     xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+			Med25519: &xdr.MuxedAccountMed25519{Id: c.id, Ed25519: key},
+		}
+
+		address, id, err := EncodeMuxedAddress(muxed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if address != c.address {
+			t.Errorf("address = %s, want %s", address, c.address)
+		}
+
+		if want := strconv.FormatUint(uint64(c.id), 10); id != want {
+			t.Errorf("id = %s, want %s", id, want)
+		}
+	}
+
+	plain := xdr.MuxedAccount{Type: xdr.CryptoKeyTypeKeyTypeEd25519, Ed25519: &key}
+
+	if address, id, _ := EncodeMuxedAddress(plain); address != "" || id != "" {
+		t.Errorf("plain account encoded as %s, %s", address, id)
+	}
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// ReadXdrRecord reads a single XDR record prefixed with 4-byte record mark, as written by stellar-core.
+// It returns io.EOF if there are no more records and io.ErrUnexpectedEOF if the record is incomplete.
+func ReadXdrRecord(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header) & 0x7fffffff
+	record := make([]byte, length)
+
+	if _, err := io.ReadFull(r, record); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return record, nil
+}